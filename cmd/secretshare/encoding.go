@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// mnemonicLineLength is the number of words per line of the mnemonic
+// encoding.
+const mnemonicLineLength = 8
+
+// encode encodes binary data using the named encoding.
+//
+// Returns an error if the encoding is unknown.
+func encode(data []byte, encoding string) (string, error) {
+	switch encoding {
+	case "hex":
+		return hex.EncodeToString(data), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(data), nil
+	case "mnemonic":
+		return encodeMnemonic(data), nil
+	}
+
+	return "", fmt.Errorf("Unknown encoding '%s'", encoding)
+}
+
+// decode decodes data previously encoded with `encode`. Leading and trailing
+// whitespace is ignored.
+//
+// Returns an error if the encoding is unknown, or the data is not validly
+// encoded.
+func decode(text string, encoding string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+
+	switch encoding {
+	case "hex":
+		return hex.DecodeString(text)
+	case "base64":
+		return base64.StdEncoding.DecodeString(text)
+	case "mnemonic":
+		return decodeMnemonic(text)
+	}
+
+	return nil, fmt.Errorf("Unknown encoding '%s'", encoding)
+}
+
+// encodeMnemonic encodes each byte of the data as one word of the word list.
+func encodeMnemonic(data []byte) string {
+	var b strings.Builder
+
+	for i, x := range data {
+		if i > 0 && i%mnemonicLineLength == 0 {
+			b.WriteString("\n")
+		} else if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(wordlist[x])
+	}
+
+	return b.String()
+}
+
+// decodeMnemonic decodes whitespace-separated words of the word list, as
+// created by `encodeMnemonic`. Words are case-insensitive.
+//
+// Returns an error if any word is not part of the word list.
+func decodeMnemonic(text string) ([]byte, error) {
+	words := strings.Fields(text)
+	data := make([]byte, len(words))
+
+	for i, word := range words {
+		word = strings.ToLower(word)
+
+		idx := sort.SearchStrings(wordlist[:], word)
+		if idx == len(wordlist) || wordlist[idx] != word {
+			return nil, fmt.Errorf("Unknown word '%s'", word)
+		}
+
+		data[i] = byte(idx)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+func TestWordlist(t *testing.T) {
+	if !sort.StringsAreSorted(wordlist[:]) {
+		t.Errorf("Expected word list to be sorted")
+	}
+
+	seen := make(map[string]bool)
+	for _, word := range wordlist {
+		if seen[word] {
+			t.Errorf("Duplicate word '%s' in word list", word)
+		}
+		seen[word] = true
+	}
+}
+
+func TestEncode(t *testing.T) {
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	for _, encoding := range []string{"hex", "base64", "mnemonic"} {
+		encoded, err := encode(data, encoding)
+		if err != nil {
+			t.Fatalf("Error encoding with %s: %v", encoding, err)
+		}
+
+		decoded, err := decode("  "+encoded+"\n", encoding)
+		if err != nil {
+			t.Fatalf("Error decoding with %s: %v", encoding, err)
+		}
+
+		if !bytes.Equal(data, decoded) {
+			t.Errorf("Expected %s encoding to round-trip; got %x", encoding, decoded)
+		}
+	}
+
+	_, err := encode(data, "unknown")
+	if err == nil {
+		t.Errorf("Expected error for unknown encoding; got none")
+	}
+}
+
+func TestDecodeMnemonic(t *testing.T) {
+	actual, err := decode("ACID zephyr\nacorn", "mnemonic")
+	if err != nil {
+		t.Fatalf("Error decoding mnemonic: %v", err)
+	}
+	if !bytes.Equal(actual, []byte{0x00, 0xff, 0x01}) {
+		t.Errorf("Expected 00ff01; got %x", actual)
+	}
+
+	_, err = decode("acid notaword", "mnemonic")
+	if err == nil {
+		t.Errorf("Expected error for unknown word; got none")
+	}
+
+	_, err = decode("acid", "unknown")
+	if err == nil {
+		t.Errorf("Expected error for unknown encoding; got none")
+	}
+}
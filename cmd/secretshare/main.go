@@ -0,0 +1,256 @@
+// Command secretshare splits a secret into shares, and combines shares back
+// into the secret, using t-out-of-n Shamir secret sharing.
+//
+// Usage:
+//
+//	secretshare split [-t 3] [-n 5] [-field gf256] [-encoding hex] [-in FILE] [-out PREFIX] [-force]
+//	secretshare combine [-encoding hex] [-out FILE] SHARE-FILE...
+//
+// `split` reads the secret from standard input or a file, and writes one file
+// per share, named `PREFIX.ID`, refusing to overwrite existing files unless
+// `-force` is given. `combine` reads the given share files, and
+// writes the secret to standard output or a file. Shares carry the field they
+// were created in, so it need not be specified when combining.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments, excluding the program
+// name.
+//
+// Returns the exit code of the command.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "split":
+		err = split(args[1:], stdin, stderr)
+	case "combine":
+		err = combine(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "Unknown command '%s'\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// usage prints a short usage message to `w`.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  secretshare split [flags]")
+	fmt.Fprintln(w, "  secretshare combine [flags] SHARE-FILE...")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Run 'secretshare split -h' or 'secretshare combine -h' for a list of flags.")
+}
+
+// split implements the `split` subcommand.
+func split(args []string, stdin io.Reader, stderr io.Writer) error {
+	flags := flag.NewFlagSet("split", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	t := flags.Int("t", 3, "Number of shares required to recover the secret")
+	n := flags.Int("n", 5, "Number of shares to create")
+	fieldName := flags.String("field", "gf256", "Field to share in: "+strings.Join(gf.FieldNames(), ", ")+", or a decimal prime")
+	encoding := flags.String("encoding", "hex", "Encoding of share files: hex, base64 or mnemonic")
+	in := flags.String("in", "-", "File to read the secret from, or - for standard input")
+	out := flags.String("out", "share", "Prefix of share files, which are named PREFIX.ID")
+	force := flags.Bool("force", false, "Overwrite existing share files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 0 {
+		return fmt.Errorf("Unexpected arguments: %v", flags.Args())
+	}
+
+	field, err := parseField(*fieldName)
+	if err != nil {
+		return err
+	}
+
+	if err := gf.ValidateField(field, *n); err != nil {
+		return err
+	}
+
+	secret, err := readInput(*in, stdin)
+	if err != nil {
+		return err
+	}
+
+	shares, err := secretshare.SplitBytes(secret, *t, *n, field)
+	if err != nil {
+		return err
+	}
+
+	contents := make([][]byte, len(shares))
+	for i, share := range shares {
+		data, err := share.MarshalBinary()
+		if err != nil {
+			return err
+		}
+
+		encoded, err := encode(data, *encoding)
+		if err != nil {
+			return err
+		}
+		contents[i] = []byte(encoded + "\n")
+	}
+
+	// Share files written before an error are removed again, so no
+	// incomplete set of shares is left behind.
+	var written []string
+	for i, share := range shares {
+		path := fmt.Sprintf("%s.%d", *out, share.ID)
+		if err := writeShareFile(path, contents[i], *force); err != nil {
+			for _, path := range written {
+				os.Remove(path)
+			}
+			return err
+		}
+		written = append(written, path)
+	}
+
+	return nil
+}
+
+// writeShareFile writes `content` to a new file at `path`.
+//
+// Returns an error if the file already exists, unless `force` is set, in which
+// case it is overwritten.
+func writeShareFile(path string, content []byte, force bool) error {
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		mode |= os.O_EXCL
+	}
+
+	f, err := os.OpenFile(path, mode, 0600)
+	if os.IsExist(err) {
+		return fmt.Errorf("Share file %s already exists; use -force to overwrite it", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// combine implements the `combine` subcommand.
+func combine(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("combine", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	encoding := flags.String("encoding", "hex", "Encoding of share files: hex, base64 or mnemonic")
+	out := flags.String("out", "-", "File to write the secret to, or - for standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("No share files given")
+	}
+
+	shares := make([]secretshare.ByteShare, flags.NArg())
+	for i, path := range flags.Args() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		data, err := decode(string(content), *encoding)
+		if err != nil {
+			return fmt.Errorf("Invalid share file %s: %v", path, err)
+		}
+
+		if err := shares[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("Invalid share file %s: %v", path, err)
+		}
+	}
+
+	if shares[0].Order == nil {
+		return fmt.Errorf("Share file %s does not specify a field", flags.Arg(0))
+	}
+
+	field, err := fieldFromOrder(shares[0].Order)
+	if err != nil {
+		return err
+	}
+
+	secret, err := secretshare.CombineBytes(shares, field)
+	if err != nil {
+		return err
+	}
+
+	if *out == "-" {
+		_, err = stdout.Write(secret)
+		return err
+	}
+
+	return os.WriteFile(*out, secret, 0600)
+}
+
+// readInput reads all data from the file at `path`, or from `stdin` if the
+// path is `-`.
+func readInput(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// parseField returns the named field, see `gf.NamedField`, or the prime field
+// of the given order.
+func parseField(name string) (gf.Field, error) {
+	if field, err := gf.NamedField(name); err == nil {
+		return field, nil
+	}
+
+	var p = &big.Int{}
+	if _, ok := p.SetString(name, 10); !ok {
+		return nil, fmt.Errorf("Unknown field '%s'", name)
+	}
+
+	return gf.NewPrimeField(p)
+}
+
+// fieldFromOrder returns the field with the given number of elements.
+func fieldFromOrder(order *big.Int) (gf.Field, error) {
+	if order.Cmp(gf.GF256{}.Order()) == 0 {
+		return gf.GF256{}, nil
+	}
+
+	return gf.NewPrimeField(order)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("\x00\x00correct horse battery staple\n")
+
+	checks := []struct {
+		field    string
+		encoding string
+	}{
+		{"gf256", "hex"},
+		{"p127", "base64"},
+		{"p521", "mnemonic"},
+		{"ed25519", "hex"},
+		{"secp256k1", "base64"},
+		{"p256", "mnemonic"},
+		{"1019", "hex"},
+	}
+
+	for _, check := range checks {
+		dir := t.TempDir()
+		prefix := filepath.Join(dir, "share")
+
+		var stdout, stderr bytes.Buffer
+		args := []string{"split", "-t", "3", "-n", "5", "-field", check.field, "-encoding", check.encoding, "-out", prefix}
+		code := run(args, bytes.NewReader(secret), &stdout, &stderr)
+		if code != 0 {
+			t.Fatalf("Expected split to succeed; got exit code %d: %s", code, stderr.String())
+		}
+
+		stdout.Reset()
+		args = []string{
+			"combine", "-encoding", check.encoding,
+			fmt.Sprintf("%s.%d", prefix, 5),
+			fmt.Sprintf("%s.%d", prefix, 2),
+			fmt.Sprintf("%s.%d", prefix, 3),
+		}
+		code = run(args, nil, &stdout, &stderr)
+		if code != 0 {
+			t.Fatalf("Expected combine to succeed; got exit code %d: %s", code, stderr.String())
+		}
+
+		if !bytes.Equal(stdout.Bytes(), secret) {
+			t.Errorf("Expected to recover %q in field %s; got %q", secret, check.field, stdout.Bytes())
+		}
+	}
+}
+
+func TestSplitInvalidInputs(t *testing.T) {
+	dir := t.TempDir()
+	prefix := filepath.Join(dir, "share")
+
+	checks := [][]string{
+		{"split", "-field", "unknown", "-out", prefix},
+		{"split", "-field", "1024", "-out", prefix},
+		{"split", "-encoding", "unknown", "-out", prefix},
+		{"split", "-t", "6", "-n", "5", "-out", prefix},
+		{"split", "-out", prefix, "extra"},
+	}
+
+	for _, args := range checks {
+		var stdout, stderr bytes.Buffer
+		code := run(args, strings.NewReader("secret"), &stdout, &stderr)
+		if code == 0 {
+			t.Errorf("Expected %v to fail; got exit code 0", args)
+		}
+	}
+}
+
+func TestSplitExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	prefix := filepath.Join(dir, "share")
+
+	existing := []byte("existing\n")
+	if err := os.WriteFile(prefix+".3", existing, 0600); err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"split", "-out", prefix}, strings.NewReader("secret"), &stdout, &stderr)
+	if code == 0 {
+		t.Errorf("Expected split to fail if share file exists; got exit code 0")
+	}
+
+	content, err := os.ReadFile(prefix + ".3")
+	if err != nil {
+		t.Fatalf("Error reading file: %v", err)
+	}
+	if !bytes.Equal(content, existing) {
+		t.Errorf("Expected existing share file to be kept; got %q", content)
+	}
+
+	if _, err := os.Stat(prefix + ".1"); !os.IsNotExist(err) {
+		t.Errorf("Expected share files written before the error to be removed; got %v", err)
+	}
+
+	code = run([]string{"split", "-force", "-out", prefix}, strings.NewReader("secret"), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Expected split with -force to succeed; got exit code %d: %s", code, stderr.String())
+	}
+
+	content, err = os.ReadFile(prefix + ".3")
+	if err != nil {
+		t.Fatalf("Error reading file: %v", err)
+	}
+	if bytes.Equal(content, existing) {
+		t.Errorf("Expected existing share file to be overwritten with -force; was not")
+	}
+}
+
+func TestCombineInvalidInputs(t *testing.T) {
+	dir := t.TempDir()
+	prefix := filepath.Join(dir, "share")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"split", "-t", "2", "-n", "2", "-out", prefix}, strings.NewReader("secret"), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Expected split to succeed; got exit code %d: %s", code, stderr.String())
+	}
+
+	checks := [][]string{
+		{"combine"},
+		{"combine", filepath.Join(dir, "missing")},
+		{"combine", "-encoding", "base64", prefix + ".1", prefix + ".2"},
+		{"combine", prefix + ".1", prefix + ".1"},
+	}
+
+	for _, args := range checks {
+		code := run(args, nil, &stdout, &stderr)
+		if code == 0 {
+			t.Errorf("Expected %v to fail; got exit code 0", args)
+		}
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{}, nil, &stdout, &stderr); code == 0 {
+		t.Errorf("Expected missing command to fail; got exit code 0")
+	}
+
+	if code := run([]string{"unknown"}, nil, &stdout, &stderr); code == 0 {
+		t.Errorf("Expected unknown command to fail; got exit code 0")
+	}
+
+	if code := run([]string{"help"}, nil, &stdout, &stderr); code != 0 {
+		t.Errorf("Expected help to succeed; got exit code %d", code)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+// wordlist maps each byte value to a word for the mnemonic share encoding.
+//
+// Words are distinct and sorted, so a byte may be looked up by index and a
+// word by binary search.
+var wordlist = [256]string{
+	"acid", "acorn", "actor", "adapt", "agent", "alarm", "alert", "alien",
+	"alpha", "amber", "anchor", "ankle", "apple", "april", "arena", "armor",
+	"atlas", "atom", "audio", "autumn", "avocado", "bagel", "baker", "bamboo",
+	"banana", "banjo", "basil", "beach", "beacon", "bear", "beaver", "berry",
+	"bicycle", "bird", "bishop", "blade", "blossom", "blue", "boat", "bolt",
+	"bonus", "bottle", "bowl", "brave", "bread", "brick", "bronze", "broom",
+	"bubble", "bucket", "buffalo", "butter", "cabin", "cactus", "camel", "camera",
+	"canoe", "canyon", "carbon", "cargo", "carrot", "cedar", "cello", "chalk",
+	"cherry", "chess", "cider", "circus", "citrus", "clock", "cloud", "cobra",
+	"coconut", "comet", "copper", "coral", "coyote", "crane", "crater", "crystal",
+	"cube", "dancer", "delta", "denim", "desert", "diamond", "dolphin", "donkey",
+	"dragon", "drum", "eagle", "echo", "eclipse", "elbow", "elder", "ember",
+	"engine", "falcon", "feather", "fence", "fern", "fiddle", "finch", "flame",
+	"flute", "forest", "fox", "galaxy", "garden", "garlic", "gecko", "giant",
+	"ginger", "glacier", "globe", "goblin", "gorilla", "grape", "gravel", "guitar",
+	"hammer", "harp", "hawk", "hazel", "helmet", "hermit", "honey", "horizon",
+	"hotel", "husky", "igloo", "iris", "island", "ivory", "jacket", "jaguar",
+	"jelly", "jewel", "jungle", "kayak", "kernel", "kiwi", "koala", "ladder",
+	"lagoon", "lantern", "lemon", "leopard", "lily", "lion", "lizard", "lotus",
+	"lunar", "magnet", "mango", "maple", "meadow", "melon", "meteor", "mint",
+	"mirror", "moose", "mosaic", "mountain", "mustard", "nebula", "nickel", "nutmeg",
+	"oasis", "ocean", "olive", "opal", "orbit", "orchid", "otter", "owl",
+	"paddle", "palace", "panda", "paper", "parrot", "pearl", "pebble", "pelican",
+	"pepper", "piano", "pine", "planet", "plum", "pocket", "polar", "poppy",
+	"potato", "prism", "puffin", "pumpkin", "quartz", "quill", "rabbit", "radar",
+	"radio", "reef", "rhino", "ribbon", "river", "robin", "rose", "ruby",
+	"saddle", "salmon", "sandal", "scarf", "sesame", "shadow", "shark", "shell",
+	"sketch", "sloth", "snow", "socket", "sonic", "spruce", "squid", "stamp",
+	"star", "stone", "sugar", "summit", "sunset", "swan", "tango", "temple",
+	"thunder", "tiger", "timber", "toast", "topaz", "torch", "tower", "tulip",
+	"tundra", "umbrella", "unicorn", "valley", "velvet", "violin", "wagon", "walnut",
+	"walrus", "whale", "willow", "wizard", "wolf", "yacht", "zebra", "zephyr",
+}
@@ -0,0 +1,800 @@
+// Package dkg implements distributed key generation as proposed by Gennaro,
+// Jarecki, Krawczyk and Rabin, which improves on Pedersen's DKG.
+//
+// Every participant deals a random secret using Pedersen's verifiable secret
+// sharing, and the shared key is the sum of the secrets of all qualified
+// dealers. No single party ever learns the key, and unlike with Feldman
+// commitments, a malicious party cannot bias the public key.
+//
+// The protocol is run by a `Participant` state machine, which is driven by an
+// external transport in synchronous rounds: All messages returned by `Start`
+// or `Next` are delivered with `Receive`, after which `Next` is called to
+// advance to the following phase. Broadcast messages must be delivered to all
+// participants consistently.
+package dkg
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+	"sort"
+)
+
+// Broadcast is the recipient of messages sent to all participants.
+const Broadcast = 0
+
+// Phase is a phase of the protocol, in which participants collect the
+// messages of a single round.
+type Phase int
+
+const (
+	// PhaseDeal collects the dealings of all participants.
+	PhaseDeal Phase = iota
+	// PhaseComplain collects complaints about invalid dealings.
+	PhaseComplain
+	// PhaseJustify collects the shares dealers reveal to answer complaints.
+	PhaseJustify
+	// PhaseCommit collects the public commitments of qualified dealers.
+	PhaseCommit
+	// PhaseExtractComplain collects complaints about public commitments.
+	PhaseExtractComplain
+	// PhaseReveal collects shares of dealers whose public commitments are
+	// reconstructed.
+	PhaseReveal
+	// PhaseDone is reached once the protocol has finished.
+	PhaseDone
+)
+
+var phaseNames = []string{"deal", "complain", "justify", "commit", "extract-complain", "reveal", "done"}
+
+// String returns the name of the phase.
+func (phase Phase) String() string {
+	if phase < 0 || int(phase) >= len(phaseNames) {
+		return fmt.Sprintf("phase %d", int(phase))
+	}
+
+	return phaseNames[phase]
+}
+
+// Message is a message sent between participants.
+type Message interface {
+	// Sender returns the ID of the sending participant.
+	Sender() int
+	// Recipient returns the ID of the receiving participant, or `Broadcast`
+	// if the message is sent to all participants.
+	Recipient() int
+}
+
+// Header holds the sender and recipient of a message.
+type Header struct {
+	From int
+	To   int
+}
+
+// Sender returns the ID of the sending participant.
+func (header Header) Sender() int {
+	return header.From
+}
+
+// Recipient returns the ID of the receiving participant.
+func (header Header) Recipient() int {
+	return header.To
+}
+
+// DealMessage privately sends a dealer's share to a single participant.
+type DealMessage struct {
+	Header
+	Share secretshare.PedersenShare
+}
+
+// CommitmentMessage broadcasts the Pedersen commitments to a dealer's
+// polynomials.
+type CommitmentMessage struct {
+	Header
+	Commitments secretshare.PedersenCommitments
+}
+
+// ComplaintMessage broadcasts a complaint about a missing or invalid share
+// received from a dealer.
+type ComplaintMessage struct {
+	Header
+	// ID of the dealer complained about
+	Dealer int
+}
+
+// JustificationMessage broadcasts the share of a complaining participant,
+// with which the dealer answers the complaint.
+type JustificationMessage struct {
+	Header
+	Share secretshare.PedersenShare
+}
+
+// PublicCommitmentMessage broadcasts the Feldman commitments `g^{a_k}` to a
+// qualified dealer's polynomial, which reveal its public key `g^{a_0}`.
+type PublicCommitmentMessage struct {
+	Header
+	Commitments secretshare.FeldmanCommitments
+}
+
+// ExtractionComplaintMessage broadcasts a complaint about Feldman commitments
+// inconsistent with a share received from a dealer. The share is revealed,
+// such that all participants can check the complaint.
+type ExtractionComplaintMessage struct {
+	Header
+	// ID of the dealer complained about
+	Dealer int
+	Share  secretshare.PedersenShare
+}
+
+// RevealMessage broadcasts a participant's share of a dealer whose Feldman
+// commitments are reconstructed publicly.
+type RevealMessage struct {
+	Header
+	// ID of the dealer whose share is revealed
+	Dealer int
+	Share  secretshare.PedersenShare
+}
+
+// Result is the outcome of the protocol for a single participant.
+type Result struct {
+	// Share of the participant of the joint secret key
+	Share secretshare.Share
+	// Feldman commitments to the joint polynomial, which allow verifying
+	// every participant's share
+	Commitments secretshare.FeldmanCommitments
+	// IDs of the qualified dealers, whose secrets sum up to the key
+	Qualified []int
+}
+
+// PublicKey returns the public key `g^x` of the joint secret key `x`.
+func (result Result) PublicKey() *big.Int {
+	return result.Commitments.Values[0]
+}
+
+// Participant is a single party taking part in the protocol.
+type Participant struct {
+	// ID of the participant, which is its x-coordinate in all sharings
+	ID int
+
+	ids    []int
+	t      int
+	params secretshare.PedersenParams
+	phase  Phase
+
+	value    gf.Polynomial // Polynomial of the own dealing
+	blinding gf.Polynomial // Blinding polynomial of the own dealing
+
+	shares         map[int]secretshare.PedersenShare       // Shares received, by dealer
+	commitments    map[int]secretshare.PedersenCommitments // Pedersen commitments, by dealer
+	complaints     map[int]map[int]bool                    // Complaining IDs, by dealer
+	justifications map[int]map[int]secretshare.PedersenShare
+	qualified      []int
+	public         map[int]secretshare.FeldmanCommitments // Feldman commitments, by dealer
+	extraction     map[int]map[int]secretshare.PedersenShare
+	reconstruct    []int
+	revealed       map[int]map[int]secretshare.PedersenShare
+	result         *Result
+}
+
+// NewParticipant creates the participant with ID `id` of a run of the
+// protocol among the participants `ids`, which generates a key shared with
+// threshold `t`.
+//
+// It is required that:
+// - 1 < t <= len(ids)
+// - ids are unique, non-zero elements of the scalar field, including `id`
+// - `params` are valid, see `secretshare.PedersenParams.Validate`
+//
+// An error is returned if any of the requirements are violated.
+func NewParticipant(id int, ids []int, t int, params secretshare.PedersenParams) (*Participant, error) {
+	field := params.Group.ScalarField()
+
+	if t <= 1 || t > len(ids) {
+		return nil, fmt.Errorf("%w: t = %d, n = %d", secretshare.ErrThreshold, t, len(ids))
+	}
+
+	seen := make(map[int]bool)
+	for _, other := range ids {
+		if other == 0 {
+			return nil, secretshare.ErrZeroID
+		}
+
+		if other < 0 || !field.IsGroupElement(big.NewInt(int64(other))) {
+			return nil, fmt.Errorf("%w: participant ID %d", secretshare.ErrNotInField, other)
+		}
+
+		if seen[other] {
+			return nil, fmt.Errorf("%w %d supplied", secretshare.ErrDuplicateID, other)
+		}
+		seen[other] = true
+	}
+
+	if !seen[id] {
+		return nil, fmt.Errorf("Participant ID %d is not among the participants", id)
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]int, len(ids))
+	copy(sorted, ids)
+	sort.Ints(sorted)
+
+	return &Participant{
+		ID:             id,
+		ids:            sorted,
+		t:              t,
+		params:         params,
+		phase:          PhaseDeal,
+		shares:         make(map[int]secretshare.PedersenShare),
+		commitments:    make(map[int]secretshare.PedersenCommitments),
+		complaints:     make(map[int]map[int]bool),
+		justifications: make(map[int]map[int]secretshare.PedersenShare),
+		public:         make(map[int]secretshare.FeldmanCommitments),
+		extraction:     make(map[int]map[int]secretshare.PedersenShare),
+		revealed:       make(map[int]map[int]secretshare.PedersenShare),
+	}, nil
+}
+
+// Phase returns the current phase of the participant.
+func (p *Participant) Phase() Phase {
+	return p.phase
+}
+
+// Start deals the participant's random secret, returning the private shares
+// of all other participants and the broadcast Pedersen commitments.
+//
+// Returns an error if the dealing has already been started.
+func (p *Participant) Start() ([]Message, error) {
+	field := p.params.Group.ScalarField()
+
+	if p.value.Field != nil {
+		return nil, fmt.Errorf("Dealing has already been started")
+	}
+
+	value, err := gf.RandomPolynomial(p.t-1, field)
+	if err != nil {
+		return nil, err
+	}
+
+	blinding, err := gf.RandomPolynomial(p.t-1, field)
+	if err != nil {
+		return nil, err
+	}
+	p.value = value
+	p.blinding = blinding
+
+	commitments := secretshare.PedersenCommitments{Params: p.params, Values: make([]*big.Int, p.t)}
+	for k := range commitments.Values {
+		// g^{a_k} h^{b_k}
+		commitments.Values[k] = p.params.Commit(value.Coefficients[k], blinding.Coefficients[k])
+	}
+
+	var out []Message
+	for _, id := range p.ids {
+		share, err := p.deal(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if id == p.ID {
+			p.shares[p.ID] = share
+			continue
+		}
+		out = append(out, &DealMessage{Header: Header{From: p.ID, To: id}, Share: share})
+	}
+
+	out, err = p.broadcast(out, &CommitmentMessage{Header: Header{From: p.ID, To: Broadcast}, Commitments: commitments})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Receive handles a message of the current phase.
+//
+// Returns an error if the message does not belong to the current phase, is
+// not addressed to the participant, is sent by an unknown participant, or
+// duplicates a previous message.
+func (p *Participant) Receive(msg Message) error {
+	if msg.Recipient() != Broadcast && msg.Recipient() != p.ID {
+		return fmt.Errorf("Message for ID %d delivered to ID %d", msg.Recipient(), p.ID)
+	}
+
+	if !p.isParticipant(msg.Sender()) {
+		return fmt.Errorf("Message from unknown ID %d", msg.Sender())
+	}
+
+	from := msg.Sender()
+	switch m := msg.(type) {
+	case *DealMessage:
+		if err := p.expect(PhaseDeal, msg); err != nil {
+			return err
+		}
+		if _, ok := p.shares[from]; ok {
+			return fmt.Errorf("Duplicate share from ID %d", from)
+		}
+		p.shares[from] = m.Share
+
+	case *CommitmentMessage:
+		if err := p.expect(PhaseDeal, msg); err != nil {
+			return err
+		}
+		if _, ok := p.commitments[from]; ok {
+			return fmt.Errorf("Duplicate commitments from ID %d", from)
+		}
+		p.commitments[from] = m.Commitments
+
+	case *ComplaintMessage:
+		if err := p.expect(PhaseComplain, msg); err != nil {
+			return err
+		}
+		addComplaint(p.complaints, m.Dealer, from)
+
+	case *JustificationMessage:
+		if err := p.expect(PhaseJustify, msg); err != nil {
+			return err
+		}
+		if p.justifications[from] == nil {
+			p.justifications[from] = make(map[int]secretshare.PedersenShare)
+		}
+		p.justifications[from][m.Share.ID] = m.Share
+
+	case *PublicCommitmentMessage:
+		if err := p.expect(PhaseCommit, msg); err != nil {
+			return err
+		}
+		if _, ok := p.public[from]; ok {
+			return fmt.Errorf("Duplicate public commitments from ID %d", from)
+		}
+		p.public[from] = m.Commitments
+
+	case *ExtractionComplaintMessage:
+		if err := p.expect(PhaseExtractComplain, msg); err != nil {
+			return err
+		}
+		if m.Share.ID != from {
+			return fmt.Errorf("Extraction complaint from ID %d reveals share of ID %d", from, m.Share.ID)
+		}
+		if p.extraction[m.Dealer] == nil {
+			p.extraction[m.Dealer] = make(map[int]secretshare.PedersenShare)
+		}
+		p.extraction[m.Dealer][from] = m.Share
+
+	case *RevealMessage:
+		if err := p.expect(PhaseReveal, msg); err != nil {
+			return err
+		}
+		if m.Share.ID != from {
+			return fmt.Errorf("Reveal from ID %d contains share of ID %d", from, m.Share.ID)
+		}
+		if p.revealed[m.Dealer] == nil {
+			p.revealed[m.Dealer] = make(map[int]secretshare.PedersenShare)
+		}
+		p.revealed[m.Dealer][from] = m.Share
+
+	default:
+		return fmt.Errorf("Unknown message type %T", msg)
+	}
+
+	return nil
+}
+
+// Next completes the current phase with the messages received, and advances
+// to the following phase.
+//
+// Returns the messages to send in the following phase, which may be none.
+// An error is returned if the protocol has already finished, or if it cannot
+// complete because too few dealers are qualified.
+func (p *Participant) Next() ([]Message, error) {
+	var out []Message
+	var err error
+
+	if p.phase >= PhaseDone {
+		return nil, fmt.Errorf("Protocol has already finished")
+	}
+
+	// Advance first, such that the participant's own broadcasts are
+	// received in the phase they belong to.
+	p.phase++
+
+	switch p.phase {
+	case PhaseComplain:
+		out, err = p.complain()
+	case PhaseJustify:
+		out, err = p.justify()
+	case PhaseCommit:
+		out, err = p.qualify()
+	case PhaseExtractComplain:
+		out, err = p.extractComplain()
+	case PhaseReveal:
+		out, err = p.reveal()
+	case PhaseDone:
+		err = p.finish()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Result returns the outcome of the protocol.
+//
+// Returns an error if the protocol has not finished yet.
+func (p *Participant) Result() (Result, error) {
+	if p.result == nil {
+		return Result{}, fmt.Errorf("Protocol has not finished yet; in phase %s", p.phase)
+	}
+
+	return *p.result, nil
+}
+
+// complain verifies the shares received from each dealer, and complains about
+// missing or invalid ones.
+func (p *Participant) complain() ([]Message, error) {
+	var out []Message
+	var err error
+
+	for _, dealer := range p.ids {
+		if dealer == p.ID {
+			continue
+		}
+
+		// A share for another ID would pass verification, but leave the
+		// participant with a wrong share of the key.
+		share, ok := p.shares[dealer]
+		if !ok || share.ID != p.ID || !p.validDealing(dealer, share) {
+			out, err = p.broadcast(out, &ComplaintMessage{Header: Header{From: p.ID, To: Broadcast}, Dealer: dealer})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// justify answers every complaint about the participant's dealing by
+// revealing the complaining participant's share.
+func (p *Participant) justify() ([]Message, error) {
+	var out []Message
+
+	for _, id := range p.ids {
+		if !p.complaints[p.ID][id] {
+			continue
+		}
+
+		share, err := p.deal(id)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err = p.broadcast(out, &JustificationMessage{Header: Header{From: p.ID, To: Broadcast}, Share: share})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// qualify determines the set of qualified dealers, and publishes the Feldman
+// commitments of the participant's dealing if it is qualified.
+//
+// A dealer is disqualified if it did not publish commitments, if at least t
+// participants complained about it, as its secret is then revealed, or if
+// it failed to answer a complaint with a valid share.
+func (p *Participant) qualify() ([]Message, error) {
+	p.qualified = nil
+
+	for _, dealer := range p.ids {
+		if _, ok := p.commitments[dealer]; !ok || len(p.complaints[dealer]) >= p.t {
+			continue
+		}
+
+		justified := true
+		for complainer := range p.complaints[dealer] {
+			share, ok := p.justifications[dealer][complainer]
+			if !ok || share.ID != complainer || !p.validDealing(dealer, share) {
+				justified = false
+				break
+			}
+
+			// The complaining party adopts the revealed share
+			if complainer == p.ID {
+				p.shares[dealer] = share
+			}
+		}
+
+		if justified {
+			p.qualified = append(p.qualified, dealer)
+		}
+	}
+
+	if len(p.qualified) == 0 {
+		return nil, fmt.Errorf("No dealer is qualified")
+	}
+
+	if !p.isQualified(p.ID) {
+		return nil, nil
+	}
+
+	commitments := secretshare.FeldmanCommitments{Group: p.params.Group, Values: make([]*big.Int, p.t)}
+	for k, coef := range p.value.Coefficients {
+		commitments.Values[k] = p.params.Group.Commit(coef) // g^{a_k}
+	}
+
+	return p.broadcast(nil, &PublicCommitmentMessage{Header: Header{From: p.ID, To: Broadcast}, Commitments: commitments})
+}
+
+// extractComplain verifies the shares of each qualified dealer against its
+// Feldman commitments, and complains about inconsistent ones.
+func (p *Participant) extractComplain() ([]Message, error) {
+	var out []Message
+	var err error
+
+	for _, dealer := range p.qualified {
+		if dealer == p.ID || !p.publiclyValid(dealer) {
+			continue
+		}
+
+		share := p.shares[dealer]
+		if !secretshare.VerifyShare(share.Share(), p.public[dealer]) {
+			msg := &ExtractionComplaintMessage{Header: Header{From: p.ID, To: Broadcast}, Dealer: dealer, Share: share}
+			out, err = p.broadcast(out, msg)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// reveal determines the qualified dealers whose Feldman commitments must be
+// reconstructed, and reveals the participant's shares of them.
+//
+// Commitments are reconstructed if they are missing, malformed or in a
+// different group, or if a participant revealed a share which is consistent
+// with the dealer's Pedersen commitments, but not with its Feldman
+// commitments.
+func (p *Participant) reveal() ([]Message, error) {
+	var out []Message
+	var err error
+
+	p.reconstruct = nil
+	for _, dealer := range p.qualified {
+		invalid := !p.publiclyValid(dealer)
+
+		for _, share := range p.extraction[dealer] {
+			// Only commitments in the participant's group are verified
+			if !invalid && p.validDealing(dealer, share) && !secretshare.VerifyShare(share.Share(), p.public[dealer]) {
+				invalid = true
+			}
+		}
+
+		if !invalid {
+			continue
+		}
+		p.reconstruct = append(p.reconstruct, dealer)
+
+		msg := &RevealMessage{Header: Header{From: p.ID, To: Broadcast}, Dealer: dealer, Share: p.shares[dealer]}
+		out, err = p.broadcast(out, msg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// finish reconstructs the Feldman commitments of dealers which failed to
+// publish valid ones, and calculates the participant's share of the joint
+// key, `x_j = Sum for i in qualified [ s_ij ]`, as well as the commitments to
+// the joint polynomial, `A_k = Product for i in qualified [ A_ik ]`.
+func (p *Participant) finish() error {
+	group := p.params.Group
+	field := group.ScalarField()
+
+	for _, dealer := range p.reconstruct {
+		commitments, err := p.reconstructCommitments(dealer)
+		if err != nil {
+			return err
+		}
+		p.public[dealer] = commitments
+	}
+
+	var value = &big.Int{}
+	joint := secretshare.FeldmanCommitments{Group: group, Values: make([]*big.Int, p.t)}
+	for k := range joint.Values {
+		joint.Values[k] = big.NewInt(1)
+	}
+
+	for _, dealer := range p.qualified {
+		value = field.Add(value, p.shares[dealer].Value)
+
+		for k, commitment := range p.public[dealer].Values {
+			joint.Values[k] = group.Mul(joint.Values[k], commitment)
+		}
+	}
+
+	qualified := make([]int, len(p.qualified))
+	copy(qualified, p.qualified)
+
+	p.result = &Result{
+		Share: secretshare.Share{
+			ID:        p.ID,
+			Value:     value,
+			Threshold: p.t,
+			Order:     field.Order(),
+		},
+		Commitments: joint,
+		Qualified:   qualified,
+	}
+
+	return nil
+}
+
+// reconstructCommitments interpolates a dealer's polynomial from the revealed
+// shares which are consistent with its Pedersen commitments, and derives its
+// Feldman commitments.
+//
+// Returns an error if fewer than t valid shares were revealed.
+func (p *Participant) reconstructCommitments(dealer int) (secretshare.FeldmanCommitments, error) {
+	group := p.params.Group
+	commitments := secretshare.FeldmanCommitments{Group: group}
+
+	var xs, ys []*big.Int
+	for _, id := range p.ids {
+		share, ok := p.revealed[dealer][id]
+		if !ok || !p.validDealing(dealer, share) {
+			continue
+		}
+
+		xs = append(xs, big.NewInt(int64(share.ID)))
+		ys = append(ys, share.Value)
+		if len(xs) == p.t {
+			break
+		}
+	}
+
+	if len(xs) < p.t {
+		return commitments, fmt.Errorf("%w: at least %d valid shares of ID %d required; got %d", secretshare.ErrThreshold, p.t, dealer, len(xs))
+	}
+
+	pol, err := gf.InterpolatePolynomial(xs, ys, group.ScalarField())
+	if err != nil {
+		return commitments, err
+	}
+
+	commitments.Values = make([]*big.Int, p.t)
+	for k := range commitments.Values {
+		coef := big.NewInt(0)
+		if k < len(pol.Coefficients) {
+			coef = pol.Coefficients[k]
+		}
+		commitments.Values[k] = group.Commit(coef) // g^{a_k}
+	}
+
+	return commitments, nil
+}
+
+// deal calculates the participant's share for ID `id` of its own dealing.
+func (p *Participant) deal(id int) (secretshare.PedersenShare, error) {
+	x := big.NewInt(int64(id))
+
+	value, err := p.value.Evaluate(x)
+	if err != nil {
+		return secretshare.PedersenShare{}, err
+	}
+
+	blinding, err := p.blinding.Evaluate(x)
+	if err != nil {
+		return secretshare.PedersenShare{}, err
+	}
+
+	return secretshare.PedersenShare{ID: id, Value: value, Blinding: blinding}, nil
+}
+
+// validDealing checks whether a share is consistent with the Pedersen
+// commitments of a dealer, which must be of degree `t - 1` and created with
+// the participant's parameters.
+//
+// A dealer using a different `h`, whose discrete logarithm it might know, could
+// open its commitments to any value and hence bias the key.
+func (p *Participant) validDealing(dealer int, share secretshare.PedersenShare) bool {
+	commitments, ok := p.commitments[dealer]
+	if !ok || commitments.Threshold() != p.t || !sameParams(commitments.Params, p.params) {
+		return false
+	}
+
+	commitments.Params = p.params
+	return secretshare.VerifyPedersenShare(share, commitments)
+}
+
+// publiclyValid checks whether a dealer published Feldman commitments of
+// degree `t - 1` in the participant's group.
+func (p *Participant) publiclyValid(dealer int) bool {
+	commitments, ok := p.public[dealer]
+	if !ok || commitments.Threshold() != p.t || !sameGroup(commitments.Group, p.params.Group) {
+		return false
+	}
+
+	for _, value := range commitments.Values {
+		if value == nil || !p.params.Group.IsElement(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// broadcast appends a broadcast message to the outgoing messages, and
+// receives it locally, as the transport does not deliver messages back to
+// their sender.
+func (p *Participant) broadcast(out []Message, msg Message) ([]Message, error) {
+	if err := p.Receive(msg); err != nil {
+		return out, err
+	}
+
+	return append(out, msg), nil
+}
+
+// expect checks that a message belongs to the current phase.
+func (p *Participant) expect(phase Phase, msg Message) error {
+	if p.phase != phase {
+		return fmt.Errorf("Unexpected %T from ID %d in phase %s", msg, msg.Sender(), p.phase)
+	}
+
+	return nil
+}
+
+// isParticipant checks whether an ID belongs to a participant.
+func (p *Participant) isParticipant(id int) bool {
+	i := sort.SearchInts(p.ids, id)
+
+	return i < len(p.ids) && p.ids[i] == id
+}
+
+// isQualified checks whether a dealer is qualified.
+func (p *Participant) isQualified(id int) bool {
+	for _, dealer := range p.qualified {
+		if dealer == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameParams checks whether two sets of Pedersen parameters are equal.
+func sameParams(a secretshare.PedersenParams, b secretshare.PedersenParams) bool {
+	return sameGroup(a.Group, b.Group) && sameInt(a.H, b.H)
+}
+
+// sameGroup checks whether two Schnorr groups are equal, including their
+// generator.
+func sameGroup(a gf.SchnorrGroup, b gf.SchnorrGroup) bool {
+	return sameInt(a.P, b.P) && sameInt(a.Q, b.Q) && sameInt(a.G, b.G)
+}
+
+// sameInt checks whether two integers are equal, where nil only equals nil.
+func sameInt(a *big.Int, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Cmp(b) == 0
+}
+
+// addComplaint records a complaint about a dealer.
+func addComplaint(complaints map[int]map[int]bool, dealer int, from int) {
+	if complaints[dealer] == nil {
+		complaints[dealer] = make(map[int]bool)
+	}
+	complaints[dealer][from] = true
+}
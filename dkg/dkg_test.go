@@ -0,0 +1,385 @@
+package dkg
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+	"testing"
+)
+
+// testParams returns Pedersen parameters in the Schnorr group of order 1019
+// in Z*_2039.
+func testParams() secretshare.PedersenParams {
+	group := gf.SchnorrGroup{
+		P: big.NewInt(2039),
+		Q: big.NewInt(1019),
+		G: big.NewInt(4),
+	}
+
+	return secretshare.NewPedersenParams(group, []byte("dkg test"))
+}
+
+// memoryNetwork is an in-memory transport, which delivers the messages of
+// each round synchronously. Messages may be altered or dropped in transit
+// by `tamper`, which returns nil to drop a message.
+type memoryNetwork struct {
+	parties []*Participant
+	tamper  func(msg Message) Message
+}
+
+// newMemoryNetwork creates participants with IDs 1 to n.
+func newMemoryNetwork(t *testing.T, n int, threshold int) *memoryNetwork {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	net := &memoryNetwork{}
+	for _, id := range ids {
+		p, err := NewParticipant(id, ids, threshold, testParams())
+		if err != nil {
+			t.Fatalf("Error creating participant: %v", err)
+		}
+		net.parties = append(net.parties, p)
+	}
+
+	return net
+}
+
+// deliver delivers messages to their recipients, and broadcast messages to
+// all participants but their sender.
+func (net *memoryNetwork) deliver(t *testing.T, msgs []Message) {
+	for _, msg := range msgs {
+		if net.tamper != nil {
+			if msg = net.tamper(msg); msg == nil {
+				continue
+			}
+		}
+
+		for _, p := range net.parties {
+			if p.ID == msg.Sender() || (msg.Recipient() != Broadcast && msg.Recipient() != p.ID) {
+				continue
+			}
+
+			if err := p.Receive(msg); err != nil {
+				t.Fatalf("Error delivering %T to ID %d: %v", msg, p.ID, err)
+			}
+		}
+	}
+}
+
+// run runs the protocol to completion, and returns the results of all
+// participants.
+func (net *memoryNetwork) run(t *testing.T) []Result {
+	var msgs []Message
+	for _, p := range net.parties {
+		out, err := p.Start()
+		if err != nil {
+			t.Fatalf("Error starting participant %d: %v", p.ID, err)
+		}
+		msgs = append(msgs, out...)
+	}
+	net.deliver(t, msgs)
+
+	for phase := PhaseDeal; phase < PhaseDone; phase++ {
+		msgs = nil
+		for _, p := range net.parties {
+			out, err := p.Next()
+			if err != nil {
+				t.Fatalf("Error completing phase %s of participant %d: %v", phase, p.ID, err)
+			}
+			msgs = append(msgs, out...)
+		}
+		net.deliver(t, msgs)
+	}
+
+	results := make([]Result, len(net.parties))
+	for i, p := range net.parties {
+		result, err := p.Result()
+		if err != nil {
+			t.Fatalf("Error getting result of participant %d: %v", p.ID, err)
+		}
+		results[i] = result
+	}
+
+	return results
+}
+
+// checkResults checks that all participants agree on the qualified dealers
+// and the public key, that their shares are consistent with the joint
+// commitments, and that any t shares recover the private key.
+func checkResults(t *testing.T, results []Result, qualified []int) {
+	group := testParams().Group
+
+	for _, result := range results {
+		if len(result.Qualified) != len(qualified) {
+			t.Fatalf("Expected qualified dealers %v; got %v", qualified, result.Qualified)
+		}
+		for i := range qualified {
+			if result.Qualified[i] != qualified[i] {
+				t.Fatalf("Expected qualified dealers %v; got %v", qualified, result.Qualified)
+			}
+		}
+
+		if result.PublicKey().Cmp(results[0].PublicKey()) != 0 {
+			t.Errorf("Expected public key %d; got %d", results[0].PublicKey(), result.PublicKey())
+		}
+
+		if !secretshare.VerifyShare(result.Share, results[0].Commitments) {
+			t.Errorf("Expected share of ID %d to pass verification; did not", result.Share.ID)
+		}
+	}
+
+	threshold := results[0].Share.Threshold
+	shares := make([]secretshare.Share, threshold)
+	for i := range shares {
+		shares[i] = results[len(results)-1-i].Share
+	}
+
+	x, err := secretshare.TOutOfNRecover(shares, group.ScalarField())
+	if err != nil {
+		t.Fatalf("Error recovering private key: %v", err)
+	}
+	if group.Commit(x).Cmp(results[0].PublicKey()) != 0 {
+		t.Errorf("Expected g^x = %d; got %d", results[0].PublicKey(), group.Commit(x))
+	}
+}
+
+func TestDKG(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4, 5})
+}
+
+func TestDKGJustifiedComplaint(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+
+	// Dealer 1 sends an invalid share to participant 2, but answers the
+	// complaint with the valid one.
+	net.tamper = func(msg Message) Message {
+		if deal, ok := msg.(*DealMessage); ok && deal.From == 1 && deal.To == 2 {
+			share := deal.Share
+			share.Value = new(big.Int).Add(share.Value, big.NewInt(1))
+			share.Value.Mod(share.Value, big.NewInt(1019))
+
+			return &DealMessage{Header: deal.Header, Share: share}
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4, 5})
+}
+
+func TestDKGUnjustifiedComplaint(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+
+	// Dealer 1 withholds participant 2's share, and does not answer the
+	// complaint.
+	net.tamper = func(msg Message) Message {
+		if deal, ok := msg.(*DealMessage); ok && deal.From == 1 && deal.To == 2 {
+			return nil
+		}
+
+		if _, ok := msg.(*JustificationMessage); ok && msg.Sender() == 1 {
+			return nil
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	// Dealer 1 is malicious, so only the honest participants must agree
+	checkResults(t, results[1:], []int{2, 3, 4, 5})
+}
+
+func TestDKGShareForOtherID(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+
+	// Dealer 1 sends participant 2 the valid share of participant 3, which
+	// passes verification, but answers the complaint with the right share.
+	net.tamper = func(msg Message) Message {
+		if deal, ok := msg.(*DealMessage); ok && deal.From == 1 && deal.To == 2 {
+			share, err := net.parties[0].deal(3)
+			if err != nil {
+				t.Fatalf("Error dealing share: %v", err)
+			}
+
+			return &DealMessage{Header: deal.Header, Share: share}
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4, 5})
+}
+
+func TestDKGForeignPedersenParams(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+	dealer := net.parties[2]
+
+	// Dealer 3 commits using h = g^2, whose discrete logarithm it knows. Its
+	// shares are consistent with these commitments, but it could open them
+	// to any value.
+	params := testParams()
+	params.H = params.Group.Commit(big.NewInt(2))
+	net.tamper = func(msg Message) Message {
+		if commit, ok := msg.(*CommitmentMessage); ok && commit.From == 3 {
+			commitments := secretshare.PedersenCommitments{Params: params, Values: make([]*big.Int, 3)}
+			for k := range commitments.Values {
+				commitments.Values[k] = params.Commit(dealer.value.Coefficients[k], dealer.blinding.Coefficients[k])
+			}
+
+			return &CommitmentMessage{Header: commit.Header, Commitments: commitments}
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	// Dealer 3 is malicious, so only the honest participants must agree
+	honest := append([]Result{results[0], results[1]}, results[3:]...)
+	checkResults(t, honest, []int{1, 2, 4, 5})
+}
+
+func TestDKGForeignPublicCommitments(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+	dealer := net.parties[2]
+
+	// Dealer 3 publishes Feldman commitments to its polynomial using a
+	// different generator. Its commitments are reconstructed instead.
+	group := testParams().Group
+	group.G = big.NewInt(16)
+	net.tamper = func(msg Message) Message {
+		if public, ok := msg.(*PublicCommitmentMessage); ok && public.From == 3 {
+			commitments := secretshare.FeldmanCommitments{Group: group, Values: make([]*big.Int, 3)}
+			for k := range commitments.Values {
+				commitments.Values[k] = group.Commit(dealer.value.Coefficients[k])
+			}
+
+			return &PublicCommitmentMessage{Header: public.Header, Commitments: commitments}
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4, 5})
+}
+
+func TestDKGInvalidPublicCommitments(t *testing.T) {
+	net := newMemoryNetwork(t, 5, 3)
+
+	// Dealer 3 publishes Feldman commitments to a different secret, trying
+	// to bias the public key. Its commitments are reconstructed instead.
+	net.tamper = func(msg Message) Message {
+		if public, ok := msg.(*PublicCommitmentMessage); ok && public.From == 3 {
+			commitments := public.Commitments
+			values := make([]*big.Int, len(commitments.Values))
+			copy(values, commitments.Values)
+			values[0] = commitments.Group.Commit(big.NewInt(7))
+			commitments.Values = values
+
+			return &PublicCommitmentMessage{Header: public.Header, Commitments: commitments}
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4, 5})
+}
+
+func TestDKGMissingPublicCommitments(t *testing.T) {
+	net := newMemoryNetwork(t, 4, 2)
+
+	net.tamper = func(msg Message) Message {
+		if _, ok := msg.(*PublicCommitmentMessage); ok && msg.Sender() == 4 {
+			return nil
+		}
+
+		return msg
+	}
+	results := net.run(t)
+
+	checkResults(t, results, []int{1, 2, 3, 4})
+}
+
+func TestNewParticipantInvalidInputs(t *testing.T) {
+	params := testParams()
+
+	_, err := NewParticipant(1, []int{1, 2, 3}, 1, params)
+	if !errors.Is(err, secretshare.ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t <= 1; got %v", err)
+	}
+
+	_, err = NewParticipant(1, []int{1, 2, 3}, 4, params)
+	if !errors.Is(err, secretshare.ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t > n; got %v", err)
+	}
+
+	_, err = NewParticipant(1, []int{1, 2, 2}, 2, params)
+	if !errors.Is(err, secretshare.ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if IDs are not unique; got %v", err)
+	}
+
+	_, err = NewParticipant(1, []int{0, 1, 2}, 2, params)
+	if !errors.Is(err, secretshare.ErrZeroID) {
+		t.Errorf("Expected ErrZeroID if an ID is zero; got %v", err)
+	}
+
+	_, err = NewParticipant(1, []int{1, 2, 1019}, 2, params)
+	if !errors.Is(err, secretshare.ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if an ID is not in the field; got %v", err)
+	}
+
+	_, err = NewParticipant(4, []int{1, 2, 3}, 2, params)
+	if err == nil {
+		t.Errorf("Expected error if ID is not among participants; got none")
+	}
+
+	params.H = params.Group.G
+	_, err = NewParticipant(1, []int{1, 2, 3}, 2, params)
+	if err == nil {
+		t.Errorf("Expected error if h = g; got none")
+	}
+}
+
+func TestParticipantInvalidMessages(t *testing.T) {
+	ids := []int{1, 2, 3}
+	p, err := NewParticipant(1, ids, 2, testParams())
+	if err != nil {
+		t.Fatalf("Error creating participant: %v", err)
+	}
+
+	if _, err := p.Result(); err == nil {
+		t.Errorf("Expected error getting result before protocol finished; got none")
+	}
+
+	err = p.Receive(&ComplaintMessage{Header: Header{From: 2, To: Broadcast}, Dealer: 3})
+	if err == nil {
+		t.Errorf("Expected error if message belongs to a different phase; got none")
+	}
+
+	err = p.Receive(&DealMessage{Header: Header{From: 4, To: 1}})
+	if err == nil {
+		t.Errorf("Expected error if message is from unknown participant; got none")
+	}
+
+	err = p.Receive(&DealMessage{Header: Header{From: 2, To: 3}})
+	if err == nil {
+		t.Errorf("Expected error if message is for a different participant; got none")
+	}
+
+	if err := p.Receive(&DealMessage{Header: Header{From: 2, To: 1}}); err != nil {
+		t.Fatalf("Error receiving share: %v", err)
+	}
+	err = p.Receive(&DealMessage{Header: Header{From: 2, To: 1}})
+	if err == nil {
+		t.Errorf("Expected error if message is duplicated; got none")
+	}
+}
@@ -0,0 +1,287 @@
+package gf
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Normalize returns a copy of the polynomial with leading zero coefficients
+// removed.
+//
+// The zero polynomial is represented by a single zero coefficient, and hence
+// has degree 0.
+func (pol *Polynomial) Normalize() Polynomial {
+	end := len(pol.Coefficients)
+	for end > 1 && pol.Coefficients[end-1].Sign() == 0 {
+		end--
+	}
+
+	out := Polynomial{Field: pol.Field, Coefficients: make([]*big.Int, end)}
+	for i := 0; i < end; i++ {
+		out.Coefficients[i] = new(big.Int).Set(pol.Coefficients[i])
+	}
+	if end == 0 {
+		out.Coefficients = []*big.Int{big.NewInt(0)}
+	}
+
+	return out
+}
+
+// IsZero returns whether all coefficients of the polynomial are zero.
+func (pol *Polynomial) IsZero() bool {
+	for _, coef := range pol.Coefficients {
+		if coef.Sign() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal returns whether two polynomials have the same coefficients, ignoring
+// leading zeros.
+func (pol *Polynomial) Equal(other Polynomial) bool {
+	a := pol.Normalize()
+	b := other.Normalize()
+
+	if len(a.Coefficients) != len(b.Coefficients) {
+		return false
+	}
+
+	for i, coef := range a.Coefficients {
+		if coef.Cmp(b.Coefficients[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add returns the sum of two polynomials.
+//
+// Returns an error if the polynomials are in different fields.
+func (pol *Polynomial) Add(other Polynomial) (Polynomial, error) {
+	return pol.combine(other, pol.Field.Add)
+}
+
+// Sub returns the difference of two polynomials.
+//
+// Returns an error if the polynomials are in different fields.
+func (pol *Polynomial) Sub(other Polynomial) (Polynomial, error) {
+	return pol.combine(other, pol.Field.Sub)
+}
+
+// combine applies `op` to the coefficients of both polynomials pairwise,
+// treating missing coefficients as zero.
+func (pol *Polynomial) combine(other Polynomial, op func(*big.Int, *big.Int) *big.Int) (Polynomial, error) {
+	out := Polynomial{Field: pol.Field}
+
+	if err := checkSameField(pol.Field, other.Field); err != nil {
+		return out, err
+	}
+
+	size := len(pol.Coefficients)
+	if len(other.Coefficients) > size {
+		size = len(other.Coefficients)
+	}
+
+	out.Coefficients = make([]*big.Int, size)
+	for i := range out.Coefficients {
+		a, b := big.NewInt(0), big.NewInt(0)
+		if i < len(pol.Coefficients) {
+			a = pol.Coefficients[i]
+		}
+		if i < len(other.Coefficients) {
+			b = other.Coefficients[i]
+		}
+
+		out.Coefficients[i] = op(a, b)
+	}
+
+	return out.Normalize(), nil
+}
+
+// Mul returns the product of two polynomials.
+//
+// Returns an error if the polynomials are in different fields.
+func (pol *Polynomial) Mul(other Polynomial) (Polynomial, error) {
+	out := Polynomial{Field: pol.Field}
+
+	if err := checkSameField(pol.Field, other.Field); err != nil {
+		return out, err
+	}
+
+	if len(pol.Coefficients) == 0 || len(other.Coefficients) == 0 {
+		return pol.zero(), nil
+	}
+
+	out.Coefficients = make([]*big.Int, len(pol.Coefficients)+len(other.Coefficients)-1)
+	for i := range out.Coefficients {
+		out.Coefficients[i] = big.NewInt(0)
+	}
+
+	// Coefficient c_k of the product is Sum for i + j = k [ a_i * b_j ]
+	for i, a := range pol.Coefficients {
+		if a.Sign() == 0 {
+			continue
+		}
+
+		for j, b := range other.Coefficients {
+			term := pol.Field.Mul(a, b)
+			out.Coefficients[i+j] = pol.Field.Add(out.Coefficients[i+j], term)
+		}
+	}
+
+	return out.Normalize(), nil
+}
+
+// Scale returns the polynomial with all coefficients multiplied by the scalar
+// `c`.
+func (pol *Polynomial) Scale(c *big.Int) Polynomial {
+	out := Polynomial{Field: pol.Field, Coefficients: make([]*big.Int, len(pol.Coefficients))}
+
+	for i, coef := range pol.Coefficients {
+		out.Coefficients[i] = pol.Field.Mul(coef, c)
+	}
+
+	return out.Normalize()
+}
+
+// DivMod performs polynomial long division, returning the quotient `q` and
+// remainder `r` such that `pol = q * divisor + r`, where the degree of `r` is
+// less than the one of `divisor`.
+//
+// Returns an error if the divisor is the zero polynomial, or if the
+// polynomials are in different fields.
+func (pol *Polynomial) DivMod(divisor Polynomial) (Polynomial, Polynomial, error) {
+	quot := Polynomial{Field: pol.Field}
+	rem := Polynomial{Field: pol.Field}
+
+	if err := checkSameField(pol.Field, divisor.Field); err != nil {
+		return quot, rem, err
+	}
+
+	den := divisor.Normalize()
+	if den.IsZero() {
+		return quot, rem, fmt.Errorf("%w polynomial", ErrDivisionByZero)
+	}
+
+	rem = pol.Normalize()
+	if rem.Degree() < den.Degree() {
+		return pol.zero(), rem, nil
+	}
+
+	quot.Coefficients = make([]*big.Int, rem.Degree()-den.Degree()+1)
+	lead := pol.Field.MultInverse(den.Coefficients[den.Degree()])
+	for i := len(quot.Coefficients) - 1; i >= 0; i-- {
+		// Eliminate the current leading coefficient of the remainder
+		coef := pol.Field.Mul(rem.Coefficients[i+den.Degree()], lead)
+		quot.Coefficients[i] = coef
+
+		for j, d := range den.Coefficients {
+			rem.Coefficients[i+j] = pol.Field.Sub(rem.Coefficients[i+j], pol.Field.Mul(coef, d))
+		}
+	}
+
+	rem.Coefficients = rem.Coefficients[:den.Degree()]
+	if len(rem.Coefficients) == 0 {
+		rem = pol.zero()
+	}
+
+	return quot.Normalize(), rem.Normalize(), nil
+}
+
+// GCD returns the monic greatest common divisor of two polynomials, using the
+// Euclidean algorithm.
+//
+// The GCD of two zero polynomials is the zero polynomial.
+// Returns an error if the polynomials are in different fields.
+func (pol *Polynomial) GCD(other Polynomial) (Polynomial, error) {
+	if err := checkSameField(pol.Field, other.Field); err != nil {
+		return Polynomial{Field: pol.Field}, err
+	}
+
+	a := pol.Normalize()
+	b := other.Normalize()
+	for !b.IsZero() {
+		_, rem, err := a.DivMod(b)
+		if err != nil {
+			return a, err
+		}
+
+		a, b = b, rem
+	}
+
+	if a.IsZero() {
+		return a, nil
+	}
+
+	// Make the result monic
+	return a.Scale(pol.Field.MultInverse(a.Coefficients[a.Degree()])), nil
+}
+
+// Derivative returns the formal derivative of the polynomial.
+//
+// The derivative of `a_i x^i` is `i * a_i x^(i-1)`, where `i * a_i` denotes
+// adding `a_i` to itself `i` times. Computing `i` within the field keeps this
+// correct in fields of small characteristic, eg. in GF(2^8) where `i * a_i`
+// is zero for all even `i`.
+func (pol *Polynomial) Derivative() Polynomial {
+	if len(pol.Coefficients) <= 1 {
+		return pol.zero()
+	}
+
+	out := Polynomial{Field: pol.Field, Coefficients: make([]*big.Int, len(pol.Coefficients)-1)}
+
+	one := big.NewInt(1)
+	multiple := big.NewInt(0) // i, as an element of the field
+	for i := 1; i < len(pol.Coefficients); i++ {
+		multiple = pol.Field.Add(multiple, one)
+		out.Coefficients[i-1] = pol.Field.Mul(multiple, pol.Coefficients[i])
+	}
+
+	return out.Normalize()
+}
+
+// Compose returns the composition `pol(other(x))` of two polynomials.
+//
+// Like `Evaluate`, it uses Horner's method, with polynomial rather than
+// scalar arithmetic.
+//
+// Returns an error if the polynomials are in different fields.
+func (pol *Polynomial) Compose(other Polynomial) (Polynomial, error) {
+	if err := checkSameField(pol.Field, other.Field); err != nil {
+		return Polynomial{Field: pol.Field}, err
+	}
+
+	out := pol.zero()
+	for i := pol.Degree(); i >= 0; i-- {
+		product, err := out.Mul(other)
+		if err != nil {
+			return out, err
+		}
+
+		constant := Polynomial{Field: pol.Field, Coefficients: []*big.Int{pol.Coefficients[i]}}
+		out, err = product.Add(constant)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// zero returns the zero polynomial in the polynomial's field.
+func (pol *Polynomial) zero() Polynomial {
+	return Polynomial{Field: pol.Field, Coefficients: []*big.Int{big.NewInt(0)}}
+}
+
+// checkSameField verifies that two fields are of the same order, such that
+// their elements may be combined.
+func checkSameField(a Field, b Field) error {
+	if a.Order().Cmp(b.Order()) != 0 {
+		return fmt.Errorf("Polynomials over fields of order %d and %d cannot be combined", a.Order(), b.Order())
+	}
+
+	return nil
+}
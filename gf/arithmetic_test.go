@@ -0,0 +1,236 @@
+package gf
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// testPolynomial creates a polynomial with the given coefficients, ordered
+// from the lowest degree to the highest.
+func testPolynomial(field Field, coefs ...int64) Polynomial {
+	poly := Polynomial{Field: field, Coefficients: make([]*big.Int, len(coefs))}
+	for i, coef := range coefs {
+		poly.Coefficients[i] = big.NewInt(coef)
+	}
+
+	return poly
+}
+
+func TestNormalize(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	poly := testPolynomial(gf, 3, 8, 0, 0)
+	norm := poly.Normalize()
+	if norm.Degree() != 1 {
+		t.Errorf("Expected normalized polynomial of degree 1; got %d", norm.Degree())
+	}
+	if poly.Degree() != 3 {
+		t.Errorf("Expected original polynomial to remain unchanged; got degree %d", poly.Degree())
+	}
+
+	poly = testPolynomial(gf, 0, 0)
+	norm = poly.Normalize()
+	if norm.Degree() != 0 || !norm.IsZero() {
+		t.Errorf("Expected zero polynomial of degree 0; got %s", norm.String())
+	}
+
+	poly = testPolynomial(gf, 3, 8, 0)
+	if !poly.Equal(testPolynomial(gf, 3, 8)) {
+		t.Errorf("Expected polynomials differing in leading zeros to be equal")
+	}
+	if poly.Equal(testPolynomial(gf, 3, 9)) {
+		t.Errorf("Expected polynomials with different coefficients to differ")
+	}
+}
+
+func TestPolynomialArithmetic(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	a := testPolynomial(gf, 3, 8, 15) // 15 x^2 + 8x + 3
+	b := testPolynomial(gf, 2, 1)     // x + 2
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Error adding polynomials: %v", err)
+	}
+	if expected := testPolynomial(gf, 5, 9, 15); !sum.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), sum.String())
+	}
+
+	diff, err := a.Sub(b)
+	if err != nil {
+		t.Fatalf("Error subtracting polynomials: %v", err)
+	}
+	if expected := testPolynomial(gf, 1, 7, 15); !diff.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), diff.String())
+	}
+
+	// Subtracting a polynomial from itself reduces the degree
+	diff, err = a.Sub(a)
+	if err != nil {
+		t.Fatalf("Error subtracting polynomials: %v", err)
+	}
+	if !diff.IsZero() || diff.Degree() != 0 {
+		t.Errorf("Expected zero polynomial; got %s", diff.String())
+	}
+
+	product, err := a.Mul(b)
+	if err != nil {
+		t.Fatalf("Error multiplying polynomials: %v", err)
+	}
+	if expected := testPolynomial(gf, 6, 2, 4, 15); !product.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), product.String())
+	}
+
+	scaled := a.Scale(big.NewInt(3))
+	if expected := testPolynomial(gf, 9, 7, 11); !scaled.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), scaled.String())
+	}
+
+	scaled = a.Scale(big.NewInt(0))
+	if !scaled.IsZero() {
+		t.Errorf("Expected zero polynomial; got %s", scaled.String())
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	a := testPolynomial(gf, 3, 8, 15) // 15 x^2 + 8x + 3
+	b := testPolynomial(gf, 2, 1)     // x + 2
+
+	quot, rem, err := a.DivMod(b)
+	if err != nil {
+		t.Fatalf("Error dividing polynomials: %v", err)
+	}
+	if expected := testPolynomial(gf, 12, 15); !quot.Equal(expected) {
+		t.Errorf("Expected quotient %s; got %s", expected.String(), quot.String())
+	}
+	if expected := testPolynomial(gf, 13); !rem.Equal(expected) {
+		t.Errorf("Expected remainder %s; got %s", expected.String(), rem.String())
+	}
+
+	// a = quot * b + rem
+	product, _ := quot.Mul(b)
+	recombined, _ := product.Add(rem)
+	if !recombined.Equal(a) {
+		t.Errorf("Expected quotient * divisor + remainder = %s; got %s", a.String(), recombined.String())
+	}
+
+	// Dividend of lower degree than divisor
+	quot, rem, err = b.DivMod(a)
+	if err != nil {
+		t.Fatalf("Error dividing polynomials: %v", err)
+	}
+	if !quot.IsZero() || !rem.Equal(b) {
+		t.Errorf("Expected quotient 0 and remainder %s; got %s and %s", b.String(), quot.String(), rem.String())
+	}
+
+	_, _, err = a.DivMod(testPolynomial(gf, 0, 0))
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected ErrDivisionByZero if dividing by zero polynomial; got %v", err)
+	}
+
+	other := testPolynomial(GF{P: big.NewInt(53)}, 1, 1)
+	_, _, err = a.DivMod(other)
+	if err == nil {
+		t.Errorf("Expected error if polynomials are in different fields; got none")
+	}
+}
+
+func TestGCD(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	// f = (x - 1)(x - 2)(x + 3), g = 5 (x - 1)(x - 2)(x + 1)
+	f := testPolynomial(gf, 6, 10, 0, 1)
+	g := testPolynomial(gf, 10, 12, 7, 5)
+
+	gcd, err := f.GCD(g)
+	if err != nil {
+		t.Fatalf("Error calculating GCD: %v", err)
+	}
+	if expected := testPolynomial(gf, 2, 14, 1); !gcd.Equal(expected) {
+		t.Errorf("Expected GCD %s; got %s", expected.String(), gcd.String())
+	}
+
+	// Coprime polynomials
+	coprime := testPolynomial(gf, 1, 1)
+	gcd, err = coprime.GCD(testPolynomial(gf, 2, 1))
+	if err != nil {
+		t.Fatalf("Error calculating GCD: %v", err)
+	}
+	if expected := testPolynomial(gf, 1); !gcd.Equal(expected) {
+		t.Errorf("Expected GCD %s; got %s", expected.String(), gcd.String())
+	}
+
+	zero := testPolynomial(gf, 0)
+	gcd, err = zero.GCD(zero)
+	if err != nil || !gcd.IsZero() {
+		t.Errorf("Expected GCD of zero polynomials to be zero; got %s, error %v", gcd.String(), err)
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	poly := testPolynomial(gf, 3, 8, 15)
+	deriv := poly.Derivative()
+	if expected := testPolynomial(gf, 8, 13); !deriv.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), deriv.String())
+	}
+
+	poly = testPolynomial(gf, 3)
+	deriv = poly.Derivative()
+	if !deriv.IsZero() {
+		t.Errorf("Expected zero polynomial; got %s", deriv.String())
+	}
+
+	// In GF(2^8), terms of even degree vanish: d/dx (x^3 + 5x^2 + 7x + 1) = x^2 + 7
+	poly = testPolynomial(GF256{}, 1, 7, 5, 1)
+	deriv = poly.Derivative()
+	if expected := testPolynomial(GF256{}, 7, 0, 1); !deriv.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), deriv.String())
+	}
+}
+
+func TestCompose(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	a := testPolynomial(gf, 3, 8, 15) // 15 x^2 + 8x + 3
+	b := testPolynomial(gf, 2, 1)     // x + 2
+
+	composed, err := a.Compose(b)
+	if err != nil {
+		t.Fatalf("Error composing polynomials: %v", err)
+	}
+	if expected := testPolynomial(gf, 11, 0, 15); !composed.Equal(expected) {
+		t.Errorf("Expected %s; got %s", expected.String(), composed.String())
+	}
+
+	// a(b(x)) evaluates like a(b(5))
+	inner, _ := b.Evaluate(big.NewInt(5))
+	expected, _ := a.Evaluate(inner)
+	actual, _ := composed.Evaluate(big.NewInt(5))
+	if actual.Cmp(expected) != 0 {
+		t.Errorf("Expected a(b(5)) = %d; got %d", expected, actual)
+	}
+}
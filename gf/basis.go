@@ -0,0 +1,287 @@
+package gf
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// BatchInverse calculates the multiplicative inverses of all values using a
+// single field inversion.
+//
+// This is Montgomery's trick: With the prefix products `c_i = a_0 * ... * a_i`,
+// the inverse of the full product yields each inverse by walking backwards:
+// `a_i^-1 = c_{i-1} * c_i^-1`, and `c_{i-1}^-1 = a_i * c_i^-1`. This trades
+// `n` inversions for one inversion and `3 (n - 1)` multiplications.
+//
+// Returns an error if any of the values is zero or not a valid group element.
+func BatchInverse(values []*big.Int, field Field) ([]*big.Int, error) {
+	inverses := make([]*big.Int, len(values))
+
+	if len(values) == 0 {
+		return inverses, nil
+	}
+
+	prefix := make([]*big.Int, len(values))
+	acc := big.NewInt(1)
+	for i, value := range values {
+		if value == nil || !field.IsGroupElement(value) {
+			return inverses, fmt.Errorf("%w: %d", ErrNotInField, value)
+		}
+
+		if value.Sign() == 0 {
+			return inverses, fmt.Errorf("%w: zero has no multiplicative inverse", ErrDivisionByZero)
+		}
+
+		acc = field.Mul(acc, value)
+		prefix[i] = acc
+	}
+
+	inv := field.MultInverse(acc) // (a_0 * ... * a_n)^-1
+	for i := len(values) - 1; i > 0; i-- {
+		inverses[i] = field.Mul(inv, prefix[i-1]) // a_i^-1
+		inv = field.Mul(inv, values[i])           // (a_0 * ... * a_{i-1})^-1
+	}
+	inverses[0] = inv
+
+	return inverses, nil
+}
+
+// LagrangeBasis holds precomputed data for interpolating polynomials through
+// a fixed set of points `xs`.
+//
+// It stores the barycentric weights `w_j = 1 / Product for m != j [ x_j - x_m ]`,
+// which are calculated once with a single field inversion. The Lagrange
+// coefficients at any position `x` then follow as:
+// `l_j(x) = L(x) * w_j / (x - x_j)`, where `L(x) = Product for m [ x - x_m ]`
+//
+// Coefficients are cached per position, so repeated interpolations at the
+// same position, eg. recovering many secrets from shares with the same IDs,
+// cost `t` multiplications each. A basis is safe for concurrent use.
+type LagrangeBasis struct {
+	// Field the points are in
+	Field Field
+
+	xs      []*big.Int
+	weights []*big.Int
+
+	mu     sync.Mutex
+	coeffs map[string][]*big.Int
+}
+
+// NewLagrangeBasis precomputes the barycentric weights of the points `xs`.
+//
+// Returns an error if `xs` is empty, or if its points are not unique, valid
+// group elements.
+func NewLagrangeBasis(xs []*big.Int, field Field) (*LagrangeBasis, error) {
+	if len(xs) == 0 {
+		return nil, fmt.Errorf("At least one point required")
+	}
+
+	if err := checkInterpolationPoints(xs, field); err != nil {
+		return nil, err
+	}
+
+	denominators := make([]*big.Int, len(xs))
+	for j, xj := range xs {
+		den := big.NewInt(1)
+		for m, xm := range xs {
+			if m == j {
+				continue
+			}
+
+			den = field.Mul(den, field.Sub(xj, xm)) // Product [ x_j - x_m ]
+		}
+		denominators[j] = den
+	}
+
+	weights, err := BatchInverse(denominators, field)
+	if err != nil {
+		return nil, err
+	}
+
+	basis := &LagrangeBasis{
+		Field:   field,
+		xs:      make([]*big.Int, len(xs)),
+		weights: weights,
+		coeffs:  make(map[string][]*big.Int),
+	}
+	for i, x := range xs {
+		basis.xs[i] = new(big.Int).Set(x)
+	}
+
+	return basis, nil
+}
+
+// Points returns the points the basis was created for.
+func (basis *LagrangeBasis) Points() []*big.Int {
+	return basis.xs
+}
+
+// Weights returns the barycentric weights `w_j` of the basis' points.
+func (basis *LagrangeBasis) Weights() []*big.Int {
+	return basis.weights
+}
+
+// CoefficientsAt returns the Lagrange coefficients `l_j(x)` of the basis'
+// points at position `x`.
+//
+// The returned slice is shared between callers and must not be modified.
+//
+// Returns an error if `x` is not a valid group element.
+func (basis *LagrangeBasis) CoefficientsAt(x *big.Int) ([]*big.Int, error) {
+	if x == nil || !basis.Field.IsGroupElement(x) {
+		return nil, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	key := x.String()
+	basis.mu.Lock()
+	coeffs, ok := basis.coeffs[key]
+	basis.mu.Unlock()
+	if ok {
+		return coeffs, nil
+	}
+
+	coeffs, err := basis.coefficientsAt(x)
+	if err != nil {
+		return nil, err
+	}
+
+	basis.mu.Lock()
+	basis.coeffs[key] = coeffs
+	basis.mu.Unlock()
+
+	return coeffs, nil
+}
+
+// coefficientsAt calculates the Lagrange coefficients at position `x` using
+// the barycentric weights.
+func (basis *LagrangeBasis) coefficientsAt(x *big.Int) ([]*big.Int, error) {
+	field := basis.Field
+	coeffs := make([]*big.Int, len(basis.xs))
+
+	// At one of the points, l_j(x) is 1 for that point and 0 otherwise.
+	for j, xj := range basis.xs {
+		if xj.Cmp(x) == 0 {
+			for m := range coeffs {
+				coeffs[m] = big.NewInt(0)
+			}
+			coeffs[j] = big.NewInt(1)
+
+			return coeffs, nil
+		}
+	}
+
+	diffs := make([]*big.Int, len(basis.xs))
+	product := big.NewInt(1)
+	for j, xj := range basis.xs {
+		diffs[j] = field.Sub(x, xj)            // x - x_j
+		product = field.Mul(product, diffs[j]) // L(x)
+	}
+
+	inverses, err := BatchInverse(diffs, field)
+	if err != nil {
+		return coeffs, err
+	}
+
+	for j, w := range basis.weights {
+		term := field.Mul(w, inverses[j])    // w_j / (x - x_j)
+		coeffs[j] = field.Mul(product, term) // L(x) * w_j / (x - x_j)
+	}
+
+	return coeffs, nil
+}
+
+// InterpolateAt evaluates the polynomial interpolating the points `(xs, ys)`
+// at position `x`, where `xs` are the basis' points.
+//
+// Returns an error if the number of y values does not match the number of
+// points, or if any of the values is not a valid group element.
+func (basis *LagrangeBasis) InterpolateAt(ys []*big.Int, x *big.Int) (*big.Int, error) {
+	var sum = &big.Int{}
+
+	if len(ys) != len(basis.xs) {
+		return sum, fmt.Errorf("Got %d y values for %d points", len(ys), len(basis.xs))
+	}
+
+	coeffs, err := basis.CoefficientsAt(x)
+	if err != nil {
+		return sum, err
+	}
+
+	for j, y := range ys {
+		if y == nil || !basis.Field.IsGroupElement(y) {
+			return sum, fmt.Errorf("%w: %d", ErrNotInField, y)
+		}
+
+		term := basis.Field.Mul(y, coeffs[j]) // y_j * l_j(x)
+		sum = basis.Field.Add(sum, term)
+	}
+
+	return sum, nil
+}
+
+// LagrangeCache caches Lagrange bases by their set of points, such that
+// recoveries from shares with the same IDs share their precomputation.
+//
+// A cache is safe for concurrent use.
+type LagrangeCache struct {
+	// Field the points are in
+	Field Field
+
+	mu    sync.Mutex
+	bases map[string]*LagrangeBasis
+}
+
+// NewLagrangeCache initializes an empty cache of Lagrange bases in the given
+// field.
+func NewLagrangeCache(field Field) *LagrangeCache {
+	return &LagrangeCache{
+		Field: field,
+		bases: make(map[string]*LagrangeBasis),
+	}
+}
+
+// Basis returns the Lagrange basis for the points `xs`, creating it if it is
+// not cached yet.
+//
+// As the coefficients are returned in the order of the points, the same
+// points in a different order yield a different basis.
+//
+// Returns an error if the basis cannot be created, see `NewLagrangeBasis`.
+func (cache *LagrangeCache) Basis(xs []*big.Int) (*LagrangeBasis, error) {
+	key := basisKey(xs)
+
+	cache.mu.Lock()
+	basis, ok := cache.bases[key]
+	cache.mu.Unlock()
+	if ok {
+		return basis, nil
+	}
+
+	basis, err := NewLagrangeBasis(xs, cache.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	cache.bases[key] = basis
+	cache.mu.Unlock()
+
+	return basis, nil
+}
+
+// basisKey derives the cache key of a list of points.
+func basisKey(xs []*big.Int) string {
+	var b strings.Builder
+
+	for i, x := range xs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%d", x)
+	}
+
+	return b.String()
+}
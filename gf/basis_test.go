@@ -0,0 +1,193 @@
+package gf
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestBatchInverse(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	values := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(17), big.NewInt(52)}
+	inverses, err := BatchInverse(values, gf)
+	if err != nil {
+		t.Fatalf("Error calculating batch inverse: %v", err)
+	}
+
+	for i, value := range values {
+		expected := gf.MultInverse(value)
+		if inverses[i].Cmp(expected) != 0 {
+			t.Errorf("Expected %d^-1 = %d; got %d", value, expected, inverses[i])
+		}
+	}
+
+	inverses, err = BatchInverse([]*big.Int{}, gf)
+	if err != nil || len(inverses) != 0 {
+		t.Errorf("Expected no inverses of empty slice; got %v, error %v", inverses, err)
+	}
+
+	_, err = BatchInverse([]*big.Int{big.NewInt(3), big.NewInt(0)}, gf)
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected ErrDivisionByZero if inverting zero; got %v", err)
+	}
+
+	_, err = BatchInverse([]*big.Int{big.NewInt(53)}, gf)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if value is not a valid group element; got %v", err)
+	}
+}
+
+func TestLagrangeBasis(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+	xs := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(5)}
+
+	basis, err := NewLagrangeBasis(xs, gf)
+	if err != nil {
+		t.Fatalf("Error creating Lagrange basis: %v", err)
+	}
+
+	for _, x := range []int64{0, 1, 2, 4, 5, 52} {
+		expected, err := LagrangeCoefficients(xs, big.NewInt(x), gf)
+		if err != nil {
+			t.Fatalf("Error calculating Lagrange coefficients: %v", err)
+		}
+
+		// Twice, to cover the cached coefficients
+		for i := 0; i < 2; i++ {
+			actual, err := basis.CoefficientsAt(big.NewInt(x))
+			if err != nil {
+				t.Fatalf("Error calculating Lagrange coefficients: %v", err)
+			}
+
+			for j := range xs {
+				if actual[j].Cmp(expected[j]) != 0 {
+					t.Errorf("Expected l_%d(%d) = %d; got %d", j, x, expected[j], actual[j])
+				}
+			}
+		}
+	}
+
+	// p(x) = 3 + 2x + 5x^2
+	ys := []*big.Int{big.NewInt(10), big.NewInt(1), big.NewInt(32)}
+	actual, err := basis.InterpolateAt(ys, big.NewInt(2))
+	if err != nil {
+		t.Fatalf("Error interpolating: %v", err)
+	}
+	if actual.Cmp(big.NewInt(27)) != 0 {
+		t.Errorf("Expected p(2) = 27; got %d", actual)
+	}
+
+	_, err = basis.InterpolateAt(ys[:2], big.NewInt(2))
+	if err == nil {
+		t.Errorf("Expected error if number of y values does not match points; got none")
+	}
+
+	_, err = basis.CoefficientsAt(big.NewInt(53))
+	if err == nil {
+		t.Errorf("Expected error if position is not a valid group element; got none")
+	}
+
+	_, err = NewLagrangeBasis([]*big.Int{big.NewInt(1), big.NewInt(1)}, gf)
+	if err == nil {
+		t.Errorf("Expected error if points are not unique; got none")
+	}
+
+	_, err = NewLagrangeBasis([]*big.Int{}, gf)
+	if err == nil {
+		t.Errorf("Expected error if no points given; got none")
+	}
+}
+
+func TestLagrangeCache(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+	cache := NewLagrangeCache(gf)
+
+	first, err := cache.Basis([]*big.Int{big.NewInt(1), big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Error getting Lagrange basis: %v", err)
+	}
+
+	second, err := cache.Basis([]*big.Int{big.NewInt(1), big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Error getting Lagrange basis: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected cached basis for same points; got new one")
+	}
+
+	third, err := cache.Basis([]*big.Int{big.NewInt(3), big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("Error getting Lagrange basis: %v", err)
+	}
+	if first == third {
+		t.Errorf("Expected different basis for reordered points; got cached one")
+	}
+
+	_, err = cache.Basis([]*big.Int{big.NewInt(1), big.NewInt(54)})
+	if err == nil {
+		t.Errorf("Expected error if points are not valid group elements; got none")
+	}
+}
+
+func benchmarkPoints(t int) []*big.Int {
+	xs := make([]*big.Int, t)
+	for i := range xs {
+		xs[i] = big.NewInt(int64(i + 1))
+	}
+
+	return xs
+}
+
+func BenchmarkBasePolynomial(b *testing.B) {
+	gf := mersenne127Field()
+	xs := benchmarkPoints(16)
+
+	for i := 0; i < b.N; i++ {
+		for j := range xs {
+			BasePolynomial(j, xs, gf)
+		}
+	}
+}
+
+func BenchmarkLagrangeBasis(b *testing.B) {
+	gf := mersenne127Field()
+	xs := benchmarkPoints(16)
+
+	for i := 0; i < b.N; i++ {
+		basis, err := NewLagrangeBasis(xs, gf)
+		if err != nil {
+			b.Fatalf("Error creating Lagrange basis: %v", err)
+		}
+
+		basis.CoefficientsAt(big.NewInt(0))
+	}
+}
+
+func BenchmarkLagrangeBasisCached(b *testing.B) {
+	gf := mersenne127Field()
+	basis, err := NewLagrangeBasis(benchmarkPoints(16), gf)
+	if err != nil {
+		b.Fatalf("Error creating Lagrange basis: %v", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		basis.CoefficientsAt(big.NewInt(0))
+	}
+}
+
+func mersenne127Field() GF {
+	p := new(big.Int).Lsh(big.NewInt(1), 127)
+	p.Sub(p, big.NewInt(1))
+
+	return GF{P: p}
+}
@@ -0,0 +1,19 @@
+package gf
+
+import (
+	"errors"
+)
+
+// Errors returned by this package, which may be wrapped with additional
+// context. Use `errors.Is` to check for them.
+var (
+	// ErrNotInField is returned if a value is not an element of the field.
+	ErrNotInField = errors.New("Value is not an element of the field")
+	// ErrFieldTooSmall is returned if a field has too few elements for the
+	// requested operation.
+	ErrFieldTooSmall = errors.New("Field is too small")
+	// ErrDuplicatePoint is returned if interpolation points are not unique.
+	ErrDuplicatePoint = errors.New("Duplicate point")
+	// ErrDivisionByZero is returned if dividing by, or inverting, zero.
+	ErrDivisionByZero = errors.New("Division by zero")
+)
@@ -1,6 +1,7 @@
-// Package gf implements operations over finite fields *of prime order*. As
-// such only a subset of fields is supported, each corresponding to the ring of
-// integers modulo p.
+// Package gf implements operations over finite fields. Fields of prime order,
+// each corresponding to the ring of integers modulo p, are supported by `GF`,
+// and with constant-time arithmetic by `Montgomery`. The binary extension
+// field GF(2^8) is supported by `GF256`.
 package gf
 
 import (
@@ -9,6 +10,44 @@ import (
 	"math/big"
 )
 
+// Field is implemented by all finite fields supported by this package.
+//
+// Elements of any field are represented as `*big.Int` values, such that
+// polynomials, Lagrange interpolation and secret sharing may operate on any
+// field without knowing its concrete type.
+type Field interface {
+	// Add performs addition in the field.
+	Add(a *big.Int, b *big.Int) *big.Int
+	// Sub performs subtraction in the field.
+	Sub(a *big.Int, b *big.Int) *big.Int
+	// Mul performs multiplication in the field.
+	Mul(a *big.Int, b *big.Int) *big.Int
+	// Div performs division in the field.
+	Div(a *big.Int, b *big.Int) *big.Int
+	// Exp performs exponentiation in the field.
+	Exp(b *big.Int, e *big.Int) *big.Int
+	// MultInverse calculates the multiplicative inverse in the field.
+	//
+	// Zero has no inverse, and zero is returned for it. Callers which may
+	// encounter zero must check for it, eg. using `BatchInverse`.
+	MultInverse(a *big.Int) *big.Int
+	// Rand returns a random member of the field.
+	Rand() (*big.Int, error)
+	// IsGroupElement checks if a value is an element of the field.
+	IsGroupElement(x *big.Int) bool
+	// Order returns the number of elements of the field.
+	Order() *big.Int
+	// ElementSize returns the length of an encoded element in bytes.
+	ElementSize() int
+	// Encode returns the fixed-width, big-endian encoding of an element.
+	Encode(x *big.Int) []byte
+	// Decode parses an element previously encoded with Encode.
+	//
+	// Returns an error if the encoding is of invalid length, or does not
+	// represent an element of the field.
+	Decode(b []byte) (*big.Int, error)
+}
+
 // GF implements a finite field of prime order
 type GF struct {
 	P *big.Int
@@ -28,8 +67,29 @@ func NewGF(order *big.Int) (GF, error) {
 	return gf, nil
 }
 
+// NewPrimeField creates a new finite field of prime order, using a
+// specialized fixed-width implementation for well-known orders.
+//
+// These are `Mersenne127` for 2^127 - 1, and `Field256` for 2^255 - 19 and the
+// group orders of ed25519, secp256k1 and P-256. Any other order yields a `GF`.
+//
+// Returns an error if the order is not prime.
+func NewPrimeField(order *big.Int) (Field, error) {
+	if order.Cmp(mersenne127) == 0 {
+		return Mersenne127{}, nil
+	}
+
+	for _, field := range []*Field256{Curve25519Base(), Ed25519Order(), Secp256k1Order(), P256Order()} {
+		if order.Cmp(field.Order()) == 0 {
+			return field, nil
+		}
+	}
+
+	return NewGF(order)
+}
+
 // Add performs addition in the finite field `gf`.
-func (gf *GF) Add(a *big.Int, b *big.Int) *big.Int {
+func (gf GF) Add(a *big.Int, b *big.Int) *big.Int {
 	var sum = &big.Int{}
 	sum.Add(a, b)      // a + b
 	sum.Mod(sum, gf.P) // a + b mod p
@@ -38,7 +98,7 @@ func (gf *GF) Add(a *big.Int, b *big.Int) *big.Int {
 }
 
 // Sub performs subtraction in the finite field `gf`.
-func (gf *GF) Sub(a *big.Int, b *big.Int) *big.Int {
+func (gf GF) Sub(a *big.Int, b *big.Int) *big.Int {
 	var diff = &big.Int{}
 	diff.Sub(a, b)       // a - b
 	diff.Mod(diff, gf.P) // a - b mod p
@@ -47,7 +107,7 @@ func (gf *GF) Sub(a *big.Int, b *big.Int) *big.Int {
 }
 
 // Mul performs multiplication in the finite field `gf`.
-func (gf *GF) Mul(a *big.Int, b *big.Int) *big.Int {
+func (gf GF) Mul(a *big.Int, b *big.Int) *big.Int {
 	var prod = &big.Int{}
 	prod.Mul(a, b)       // a * b
 	prod.Mod(prod, gf.P) // a * b mod p
@@ -56,7 +116,7 @@ func (gf *GF) Mul(a *big.Int, b *big.Int) *big.Int {
 }
 
 // Div performs division in the finite field `gf`.
-func (gf *GF) Div(a *big.Int, b *big.Int) *big.Int {
+func (gf GF) Div(a *big.Int, b *big.Int) *big.Int {
 	var quot = &big.Int{}
 
 	inv := gf.MultInverse(b) // b^{-1}
@@ -67,7 +127,7 @@ func (gf *GF) Div(a *big.Int, b *big.Int) *big.Int {
 }
 
 // Exp performs modular exponentiation in the finite field `gf`.
-func (gf *GF) Exp(b *big.Int, e *big.Int) *big.Int {
+func (gf GF) Exp(b *big.Int, e *big.Int) *big.Int {
 	var pow = &big.Int{}
 	pow.Exp(b, e, gf.P) // b^e mod p
 
@@ -75,29 +135,39 @@ func (gf *GF) Exp(b *big.Int, e *big.Int) *big.Int {
 }
 
 // MultInverse calculates the modular multiplicative inverse in the finite
-// field `gf`.
-func (gf *GF) MultInverse(a *big.Int) *big.Int {
+// field `gf`. The inverse of zero is zero.
+func (gf GF) MultInverse(a *big.Int) *big.Int {
 	var inv = &big.Int{}
-	inv.ModInverse(a, gf.P)
+
+	// ModInverse returns nil and leaves inv unchanged if there is no inverse
+	if inv.ModInverse(a, gf.P) == nil {
+		return inv.SetInt64(0)
+	}
 
 	return inv
 }
 
 // Rand returns a random rember of the finite field `gf`.
-func (gf *GF) Rand() (*big.Int, error) {
+func (gf GF) Rand() (*big.Int, error) {
 	return rand.Int(rand.Reader, gf.P)
 }
 
 // RandomPolynomial returns a random polynomial over the finite field `gf`.
-func (gf *GF) RandomPolynomial(degree int) (Polynomial, error) {
-	poly, err := NewPolynomial(degree, *gf)
+func (gf GF) RandomPolynomial(degree int) (Polynomial, error) {
+	return RandomPolynomial(degree, gf)
+}
+
+// RandomPolynomial returns a random polynomial of given degree over the
+// finite field `field`.
+func RandomPolynomial(degree int, field Field) (Polynomial, error) {
+	poly, err := NewPolynomial(degree, field)
 
 	if err != nil {
 		return poly, err
 	}
 
 	for i := 0; i < degree+1; i++ {
-		rnd, err := gf.Rand()
+		rnd, err := field.Rand()
 		if err != nil {
 			return poly, err
 		}
@@ -109,7 +179,7 @@ func (gf *GF) RandomPolynomial(degree int) (Polynomial, error) {
 }
 
 // IsGroupElement checks if a value is an element of group `gf`.
-func (gf *GF) IsGroupElement(x *big.Int) bool {
+func (gf GF) IsGroupElement(x *big.Int) bool {
 	// x < 0
 	if x.Cmp(big.NewInt(0)) == -1 {
 		return false
@@ -122,3 +192,43 @@ func (gf *GF) IsGroupElement(x *big.Int) bool {
 
 	return true
 }
+
+// Order returns the number of elements of the finite field `gf`.
+func (gf GF) Order() *big.Int {
+	return gf.P
+}
+
+// ElementSize returns the length of an encoded element of the finite field
+// `gf` in bytes.
+func (gf GF) ElementSize() int {
+	return (gf.P.BitLen() + 7) / 8
+}
+
+// Encode returns the fixed-width, big-endian encoding of an element of the
+// finite field `gf`.
+func (gf GF) Encode(x *big.Int) []byte {
+	var red = &big.Int{}
+	red.Mod(x, gf.P)
+
+	return red.FillBytes(make([]byte, gf.ElementSize()))
+}
+
+// Decode parses an element of the finite field `gf` previously encoded with
+// Encode.
+//
+// Returns an error if the encoding is of invalid length, or does not represent
+// an element of the field.
+func (gf GF) Decode(b []byte) (*big.Int, error) {
+	var x = &big.Int{}
+
+	if len(b) != gf.ElementSize() {
+		return x, fmt.Errorf("Encoded element must be %d bytes; got %d", gf.ElementSize(), len(b))
+	}
+
+	x.SetBytes(b)
+	if !gf.IsGroupElement(x) {
+		return x, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	return x, nil
+}
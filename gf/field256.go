@@ -0,0 +1,343 @@
+package gf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// element256 is an element of a `Field256` in Montgomery form, as
+// little-endian 64-bit limbs.
+type element256 [4]uint64
+
+// Field256 implements a finite field of odd prime order below 2^256, such as
+// the group orders of common elliptic curves.
+//
+// It uses the same Montgomery arithmetic as `Montgomery`, but on fixed-size
+// arrays of four limbs, which avoids allocations and loops over a variable
+// number of limbs. All arithmetic on limbs is constant-time, which makes
+// inversion slower than the variable-time one of `math/big`.
+type Field256 struct {
+	p     element256 // Modulus, in regular form
+	pInv  uint64     // -p^{-1} mod 2^64
+	rr    element256 // R^2 mod p, to convert to Montgomery form
+	one   element256 // R mod p, ie 1 in Montgomery form
+	order *big.Int   // Modulus
+}
+
+// NewField256 creates a new finite field of odd prime order below 2^256.
+//
+// Returns an error if the order is not an odd prime, or does not fit into 256
+// bits.
+func NewField256(order *big.Int) (*Field256, error) {
+	if order.BitLen() > 256 {
+		return nil, fmt.Errorf("Field order must fit into 256 bits; %d does not", order)
+	}
+
+	mont, err := NewMontgomery(order)
+	if err != nil {
+		return nil, err
+	}
+
+	// The inverse of p modulo 2^64 only depends on its lowest limb, but R
+	// differs if the order fits into fewer than four limbs.
+	field := &Field256{pInv: mont.pInv, order: mont.order}
+	copy(field.p[:], limbs(order, 4))
+
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	copy(field.one[:], limbs(new(big.Int).Mod(r, order), 4))
+	copy(field.rr[:], limbs(new(big.Int).Exp(r, big.NewInt(2), order), 4))
+
+	return field, nil
+}
+
+// mustField256 creates a field of a well-known prime order, given in
+// hexadecimal.
+func mustField256(hex string) *Field256 {
+	order, ok := new(big.Int).SetString(hex, 16)
+	if !ok {
+		panic("invalid field order " + hex)
+	}
+
+	field, err := NewField256(order)
+	if err != nil {
+		panic(err)
+	}
+
+	return field
+}
+
+// The well-known fields are only created once, as doing so checks the
+// primality of their order.
+var (
+	curve25519Base = mustField256("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed")
+	ed25519Order   = mustField256("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed")
+	secp256k1Order = mustField256("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	p256Order      = mustField256("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551")
+)
+
+// Curve25519Base returns the base field of Curve25519, of order
+// `2^255 - 19`.
+func Curve25519Base() *Field256 {
+	return curve25519Base
+}
+
+// Ed25519Order returns the field of the order of the prime-order subgroup of
+// Curve25519, `2^252 + 27742317777372353535851937790883648493`.
+func Ed25519Order() *Field256 {
+	return ed25519Order
+}
+
+// Secp256k1Order returns the field of the order of the group of the secp256k1
+// curve.
+func Secp256k1Order() *Field256 {
+	return secp256k1Order
+}
+
+// P256Order returns the field of the order of the group of the NIST P-256
+// curve.
+func P256Order() *Field256 {
+	return p256Order
+}
+
+// add256 performs addition of two reduced elements.
+func (field *Field256) add256(a element256, b element256) element256 {
+	var sum, diff element256
+
+	var carry, borrow uint64
+	for i := 0; i < 4; i++ {
+		sum[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	for i := 0; i < 4; i++ {
+		diff[i], borrow = bits.Sub64(sum[i], field.p[i], borrow)
+	}
+
+	// Keep a + b - p unless it underflowed, including the carry out
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	return select256(borrow, sum, diff)
+}
+
+// sub256 performs subtraction of two reduced elements.
+func (field *Field256) sub256(a element256, b element256) element256 {
+	var diff element256
+
+	var borrow, carry uint64
+	for i := 0; i < 4; i++ {
+		diff[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+
+	// Add p back if a - b underflowed
+	mask := -borrow
+	for i := 0; i < 4; i++ {
+		diff[i], carry = bits.Add64(diff[i], field.p[i]&mask, carry)
+	}
+
+	return diff
+}
+
+// mul256 performs Montgomery multiplication of two reduced elements,
+// returning `a * b / R mod p`. See `Montgomery.MulElement`.
+func (field *Field256) mul256(a element256, b element256) element256 {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		var c, carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+		t[4], carry = bits.Add64(t[4], c, 0)
+		t[5] = carry
+
+		m := t[0] * field.pInv
+		hi, lo := bits.Mul64(m, field.p[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, field.p[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+		t[3], carry = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + carry
+	}
+
+	var out element256
+	var borrow uint64
+	for i := 0; i < 4; i++ {
+		out[i], borrow = bits.Sub64(t[i], field.p[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[4], 0, borrow)
+
+	return select256(borrow, element256{t[0], t[1], t[2], t[3]}, out)
+}
+
+// exp256 performs exponentiation of a reduced element, always performing both
+// a squaring and a multiplication for each bit of the exponent.
+func (field *Field256) exp256(b element256, e *big.Int) element256 {
+	size := 256
+	if e.BitLen() > size {
+		size = e.BitLen()
+	}
+
+	out := field.one
+	for i := size - 1; i >= 0; i-- {
+		out = field.mul256(out, out)
+		prod := field.mul256(out, b)
+		out = select256(uint64(e.Bit(i)), prod, out)
+	}
+
+	return out
+}
+
+// inverse256 calculates the multiplicative inverse of a reduced element using
+// Fermat's little theorem. The inverse of zero is zero.
+func (field *Field256) inverse256(a element256) element256 {
+	return field.exp256(a, new(big.Int).Sub(field.order, big.NewInt(2)))
+}
+
+// select256 returns `a` if `cond` is 1, and `b` if `cond` is 0, without
+// branching.
+func select256(cond uint64, a element256, b element256) element256 {
+	mask := -cond
+	for i := range b {
+		b[i] ^= (b[i] ^ a[i]) & mask
+	}
+
+	return b
+}
+
+// plain256 converts a value into an element in regular form, reducing it
+// first if it is outside of the field.
+func (field *Field256) plain256(x *big.Int) element256 {
+	if !field.IsGroupElement(x) {
+		x = new(big.Int).Mod(x, field.order)
+	}
+
+	var a element256
+	fillLimbs(a[:], x)
+
+	return a
+}
+
+// to256 converts a value into an element in Montgomery form.
+func (field *Field256) to256(x *big.Int) element256 {
+	return field.mul256(field.plain256(x), field.rr) // x * R^2 / R
+}
+
+// from256 converts an element from Montgomery form into its value.
+func (field *Field256) from256(a element256) *big.Int {
+	out := field.mul256(a, element256{1, 0, 0, 0}) // a R * 1 / R
+
+	return fromLimbs(out[:])
+}
+
+// Add performs addition in the finite field `field`.
+//
+// Addition does not depend on the form of its operands, so no conversion into
+// Montgomery form is needed.
+func (field *Field256) Add(a *big.Int, b *big.Int) *big.Int {
+	sum := field.add256(field.plain256(a), field.plain256(b))
+
+	return fromLimbs(sum[:])
+}
+
+// Sub performs subtraction in the finite field `field`.
+func (field *Field256) Sub(a *big.Int, b *big.Int) *big.Int {
+	diff := field.sub256(field.plain256(a), field.plain256(b))
+
+	return fromLimbs(diff[:])
+}
+
+// Mul performs multiplication in the finite field `field`.
+//
+// With only one operand in Montgomery form, `a R * b / R = a * b`, which
+// yields the product in regular form.
+func (field *Field256) Mul(a *big.Int, b *big.Int) *big.Int {
+	prod := field.mul256(field.to256(a), field.plain256(b))
+
+	return fromLimbs(prod[:])
+}
+
+// Div performs division in the finite field `field`.
+func (field *Field256) Div(a *big.Int, b *big.Int) *big.Int {
+	quot := field.mul256(field.plain256(a), field.inverse256(field.to256(b)))
+
+	return fromLimbs(quot[:])
+}
+
+// Exp performs exponentiation in the finite field `field`.
+func (field *Field256) Exp(b *big.Int, e *big.Int) *big.Int {
+	base := field.to256(b)
+	if e.Sign() < 0 {
+		base = field.inverse256(base)
+		e = new(big.Int).Neg(e)
+	}
+
+	return field.from256(field.exp256(base, e))
+}
+
+// MultInverse calculates the multiplicative inverse in the finite field
+// `field`.
+func (field *Field256) MultInverse(a *big.Int) *big.Int {
+	return field.from256(field.inverse256(field.to256(a)))
+}
+
+// Rand returns a random member of the finite field `field`.
+func (field *Field256) Rand() (*big.Int, error) {
+	return rand.Int(rand.Reader, field.order)
+}
+
+// IsGroupElement checks if a value is an element of the finite field `field`.
+func (field *Field256) IsGroupElement(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(field.order) == -1
+}
+
+// Order returns the number of elements of the finite field `field`.
+func (field *Field256) Order() *big.Int {
+	return field.order
+}
+
+// ElementSize returns the length of an encoded element of the finite field
+// `field` in bytes.
+func (field *Field256) ElementSize() int {
+	return (field.order.BitLen() + 7) / 8
+}
+
+// Encode returns the fixed-width, big-endian encoding of an element of the
+// finite field `field`.
+func (field *Field256) Encode(x *big.Int) []byte {
+	if !field.IsGroupElement(x) {
+		x = new(big.Int).Mod(x, field.order)
+	}
+
+	return x.FillBytes(make([]byte, field.ElementSize()))
+}
+
+// Decode parses an element of the finite field `field` previously encoded
+// with Encode.
+//
+// Returns an error if the encoding is of invalid length, or does not represent
+// an element of the field.
+func (field *Field256) Decode(b []byte) (*big.Int, error) {
+	var x = &big.Int{}
+
+	if len(b) != field.ElementSize() {
+		return x, fmt.Errorf("Encoded element must be %d bytes; got %d", field.ElementSize(), len(b))
+	}
+
+	x.SetBytes(b)
+	if !field.IsGroupElement(x) {
+		return x, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	return x, nil
+}
@@ -0,0 +1,144 @@
+package gf
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewField256(t *testing.T) {
+	_, err := NewField256(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Expected no error; got '%s'", err)
+	}
+
+	_, err = NewField256(big.NewInt(51))
+	if err == nil {
+		t.Errorf("Expected error for non-prime order; got none")
+	}
+
+	p521 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+	_, err = NewField256(p521)
+	if err == nil {
+		t.Errorf("Expected error for order exceeding 256 bits; got none")
+	}
+}
+
+func TestField256(t *testing.T) {
+	small, err := NewField256(big.NewInt(53))
+	if err != nil {
+		t.Fatalf("Error creating field: %v", err)
+	}
+
+	fields := map[string]*Field256{
+		"small":     small,
+		"p255":      Curve25519Base(),
+		"ed25519":   Ed25519Order(),
+		"secp256k1": Secp256k1Order(),
+		"p256":      P256Order(),
+	}
+
+	for name, field := range fields {
+		t.Run(name, func(t *testing.T) {
+			checkFieldMatchesGF(t, field)
+		})
+	}
+
+	expected := new(big.Int).Lsh(big.NewInt(1), 252)
+	expected.Add(expected, fromDecimal("27742317777372353535851937790883648493"))
+	if Ed25519Order().Order().Cmp(expected) != 0 {
+		t.Errorf("Expected ed25519 order %d; got %d", expected, Ed25519Order().Order())
+	}
+
+	if P256Order() != P256Order() {
+		t.Errorf("Expected P-256 order field to be created once; got distinct fields")
+	}
+}
+
+func TestNewPrimeField(t *testing.T) {
+	field, err := NewPrimeField(mersenne127Field().P)
+	if err != nil {
+		t.Fatalf("Error creating field: %v", err)
+	}
+	if _, ok := field.(Mersenne127); !ok {
+		t.Errorf("Expected Mersenne127 for order 2^127 - 1; got %T", field)
+	}
+
+	field, err = NewPrimeField(P256Order().Order())
+	if err != nil {
+		t.Fatalf("Error creating field: %v", err)
+	}
+	if _, ok := field.(*Field256); !ok {
+		t.Errorf("Expected Field256 for P-256 order; got %T", field)
+	}
+
+	p255, err := NamedPrime("p255")
+	if err != nil {
+		t.Fatalf("Error looking up p255: %v", err)
+	}
+	field, err = NewPrimeField(p255)
+	if err != nil {
+		t.Fatalf("Error creating field: %v", err)
+	}
+	if _, ok := field.(*Field256); !ok {
+		t.Errorf("Expected Field256 for order 2^255 - 19; got %T", field)
+	}
+
+	field, err = NewPrimeField(big.NewInt(53))
+	if err != nil {
+		t.Fatalf("Error creating field: %v", err)
+	}
+	if _, ok := field.(GF); !ok {
+		t.Errorf("Expected GF for order 53; got %T", field)
+	}
+
+	_, err = NewPrimeField(big.NewInt(51))
+	if err == nil {
+		t.Errorf("Expected error for non-prime order; got none")
+	}
+}
+
+func fromDecimal(s string) *big.Int {
+	x, _ := new(big.Int).SetString(s, 10)
+
+	return x
+}
+
+func BenchmarkField256Mul(b *testing.B) {
+	field := P256Order()
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Mul(x, y)
+	}
+}
+
+func BenchmarkField256MulBig(b *testing.B) {
+	field := GF{P: P256Order().Order()}
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Mul(x, y)
+	}
+}
+
+func BenchmarkField256Add(b *testing.B) {
+	field := P256Order()
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Add(x, y)
+	}
+}
+
+func BenchmarkField256AddBig(b *testing.B) {
+	field := GF{P: P256Order().Order()}
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Add(x, y)
+	}
+}
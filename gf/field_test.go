@@ -1,6 +1,8 @@
 package gf
 
 import (
+	"bytes"
+	"errors"
 	"math/big"
 	"testing"
 )
@@ -145,6 +147,21 @@ func TestMultInverse(t *testing.T) {
 	}
 }
 
+func TestMultInverseZero(t *testing.T) {
+	montgomery, err := NewMontgomery(big.NewInt(53))
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+
+	fields := []Field{GF{P: big.NewInt(53)}, GF256{}, montgomery, Mersenne127{}, P256Order()}
+	for _, field := range fields {
+		inv := field.MultInverse(big.NewInt(0))
+		if inv == nil || inv.Sign() != 0 {
+			t.Errorf("Expected 0^-1 = 0 in field of order %d; got %v", field.Order(), inv)
+		}
+	}
+}
+
 func TestExp(t *testing.T) {
 	gf, err := NewGF(big.NewInt(17))
 	if err != nil {
@@ -220,6 +237,81 @@ func TestRandPolyonmial(t *testing.T) {
 	}
 }
 
+func TestRandomPolynomialFields(t *testing.T) {
+	fields := []Field{
+		GF{P: big.NewInt(17)},
+		GF256{},
+	}
+
+	for _, field := range fields {
+		poly, err := RandomPolynomial(2, field)
+		if err != nil {
+			t.Errorf("Polynomial generation failed: %v", err)
+		}
+
+		if poly.Degree() != 2 {
+			t.Errorf("Expected polynomial of degree 2; got %d", poly.Degree())
+		}
+
+		for _, coef := range poly.Coefficients {
+			if !field.IsGroupElement(coef) {
+				t.Errorf("Polynomial had coefficient which is not a group element: %d", coef)
+			}
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	checks := []struct {
+		field   Field
+		x       int64
+		encoded []byte
+	}{
+		{GF{P: big.NewInt(17)}, 5, []byte{0x05}},
+		{GF{P: big.NewInt(257)}, 5, []byte{0x00, 0x05}},
+		{GF{P: big.NewInt(257)}, 256, []byte{0x01, 0x00}},
+		{GF256{}, 0xab, []byte{0xab}},
+	}
+
+	for _, check := range checks {
+		actual := check.field.Encode(big.NewInt(check.x))
+		if !bytes.Equal(actual, check.encoded) {
+			t.Errorf("Expected %d to encode to %x; got %x", check.x, check.encoded, actual)
+		}
+
+		if len(actual) != check.field.ElementSize() {
+			t.Errorf("Expected encoding of length %d; got %d", check.field.ElementSize(), len(actual))
+		}
+
+		decoded, err := check.field.Decode(actual)
+		if err != nil {
+			t.Errorf("Error decoding %x: %v", actual, err)
+		}
+		if decoded.Cmp(big.NewInt(check.x)) != 0 {
+			t.Errorf("Expected %x to decode to %d; got %d", actual, check.x, decoded)
+		}
+	}
+}
+
+func TestDecodeInvalidInputs(t *testing.T) {
+	field := GF{P: big.NewInt(257)}
+
+	_, err := field.Decode([]byte{0x01})
+	if err == nil {
+		t.Errorf("Expected error when decoding element of invalid length; got none")
+	}
+
+	_, err = field.Decode([]byte{0x01, 0x01})
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField when decoding value not in field; got %v", err)
+	}
+
+	_, err = GF256{}.Decode([]byte{0x01, 0x01})
+	if err == nil {
+		t.Errorf("Expected error when decoding element of invalid length; got none")
+	}
+}
+
 func TestIsGroupElement(t *testing.T) {
 	gf, err := NewGF(big.NewInt(17))
 	if err != nil {
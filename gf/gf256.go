@@ -0,0 +1,186 @@
+package gf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// GF256 implements the binary extension field GF(2^8).
+//
+// Elements are polynomials over GF(2) of degree at most 7, represented as
+// bytes, and reduced modulo the irreducible polynomial x^8 + x^4 + x^3 + x + 1
+// (0x11b), the same as used by AES.
+//
+// Multiplication and inversion are implemented without lookup tables or
+// data-dependent branches, so their timing does not depend on the operands.
+//
+// For compatibility with `GF`, all methods not suffixed with `Byte` operate on
+// `*big.Int` values, which must be in the range [0, 255].
+type GF256 struct{}
+
+// gf256Order is the number of elements of GF(2^8).
+const gf256Order = 256
+
+// AddByte performs addition in GF(2^8), which is a bitwise XOR.
+func (GF256) AddByte(a byte, b byte) byte {
+	return a ^ b
+}
+
+// SubByte performs subtraction in GF(2^8), which is identical to addition.
+func (GF256) SubByte(a byte, b byte) byte {
+	return a ^ b
+}
+
+// MulByte performs multiplication in GF(2^8).
+func (GF256) MulByte(a byte, b byte) byte {
+	var prod byte
+
+	for i := 0; i < 8; i++ {
+		// Add a if the lowest bit of b is set
+		prod ^= -(b & 1) & a
+		b >>= 1
+
+		// a * x, reduced by 0x11b if the highest bit was set
+		carry := -(a >> 7)
+		a = (a << 1) ^ (carry & 0x1b)
+	}
+
+	return prod
+}
+
+// InverseByte calculates the multiplicative inverse in GF(2^8).
+//
+// As the multiplicative group has order 255, a^{-1} = a^254. By convention
+// the inverse of 0 is 0.
+func (field GF256) InverseByte(a byte) byte {
+	// 254 = 0b11111110, so a^254 = a^2 * a^4 * ... * a^128
+	inv := byte(1)
+	sq := a
+	for i := 1; i < 8; i++ {
+		sq = field.MulByte(sq, sq) // a^(2^i)
+		inv = field.MulByte(inv, sq)
+	}
+
+	return inv
+}
+
+// DivByte performs division in GF(2^8).
+func (field GF256) DivByte(a byte, b byte) byte {
+	return field.MulByte(a, field.InverseByte(b))
+}
+
+// Add performs addition in the finite field `field`.
+func (field GF256) Add(a *big.Int, b *big.Int) *big.Int {
+	return byteToInt(field.AddByte(intToByte(a), intToByte(b)))
+}
+
+// Sub performs subtraction in the finite field `field`.
+func (field GF256) Sub(a *big.Int, b *big.Int) *big.Int {
+	return byteToInt(field.SubByte(intToByte(a), intToByte(b)))
+}
+
+// Mul performs multiplication in the finite field `field`.
+func (field GF256) Mul(a *big.Int, b *big.Int) *big.Int {
+	return byteToInt(field.MulByte(intToByte(a), intToByte(b)))
+}
+
+// Div performs division in the finite field `field`.
+func (field GF256) Div(a *big.Int, b *big.Int) *big.Int {
+	return byteToInt(field.DivByte(intToByte(a), intToByte(b)))
+}
+
+// Exp performs exponentiation in the finite field `field`.
+func (field GF256) Exp(b *big.Int, e *big.Int) *big.Int {
+	base := intToByte(b)
+
+	if e.Sign() == 0 {
+		return big.NewInt(1)
+	}
+	if base == 0 {
+		return big.NewInt(0)
+	}
+
+	// The multiplicative group has order 255, so we may reduce the
+	// exponent. This also takes care of negative exponents.
+	var exp = &big.Int{}
+	exp.Mod(e, big.NewInt(gf256Order-1))
+
+	pow := byte(1)
+	for i := exp.BitLen() - 1; i >= 0; i-- {
+		pow = field.MulByte(pow, pow)
+		if exp.Bit(i) == 1 {
+			pow = field.MulByte(pow, base)
+		}
+	}
+
+	return byteToInt(pow)
+}
+
+// MultInverse calculates the multiplicative inverse in the finite field
+// `field`.
+func (field GF256) MultInverse(a *big.Int) *big.Int {
+	return byteToInt(field.InverseByte(intToByte(a)))
+}
+
+// Rand returns a random member of the finite field `field`.
+func (field GF256) Rand() (*big.Int, error) {
+	buf := make([]byte, 1)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("Error reading random byte: %v", err)
+	}
+
+	return byteToInt(buf[0]), nil
+}
+
+// IsGroupElement checks if a value is an element of group `field`.
+func (field GF256) IsGroupElement(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(big.NewInt(gf256Order)) == -1
+}
+
+// RandomPolynomial returns a random polynomial over the finite field `field`.
+func (field GF256) RandomPolynomial(degree int) (Polynomial, error) {
+	return RandomPolynomial(degree, field)
+}
+
+// Order returns the number of elements of the finite field `field`.
+func (field GF256) Order() *big.Int {
+	return big.NewInt(gf256Order)
+}
+
+// ElementSize returns the length of an encoded element of the finite field
+// `field` in bytes.
+func (field GF256) ElementSize() int {
+	return 1
+}
+
+// Encode returns the single-byte encoding of an element of the finite field
+// `field`.
+func (field GF256) Encode(x *big.Int) []byte {
+	return []byte{intToByte(x)}
+}
+
+// Decode parses an element of the finite field `field` previously encoded
+// with Encode.
+//
+// Returns an error if the encoding is not exactly one byte.
+func (field GF256) Decode(b []byte) (*big.Int, error) {
+	if len(b) != 1 {
+		return &big.Int{}, fmt.Errorf("Encoded element must be 1 byte; got %d", len(b))
+	}
+
+	return byteToInt(b[0]), nil
+}
+
+// intToByte converts a field element to its byte representation.
+//
+// Values outside of the field are reduced to their lowest eight bits.
+func intToByte(x *big.Int) byte {
+	return byte(x.Uint64())
+}
+
+// byteToInt converts the byte representation of a field element to a
+// `*big.Int`.
+func byteToInt(x byte) *big.Int {
+	return big.NewInt(int64(x))
+}
@@ -0,0 +1,168 @@
+package gf
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMulByte(t *testing.T) {
+	field := GF256{}
+
+	checks := []struct {
+		a    byte
+		b    byte
+		prod byte
+	}{
+		{0x57, 0x83, 0xc1},
+		{0x57, 0x13, 0xfe},
+		{0x01, 0xab, 0xab},
+		{0x00, 0xab, 0x00},
+		{0x02, 0x80, 0x1b},
+	}
+
+	for _, check := range checks {
+		actual := field.MulByte(check.a, check.b)
+		if actual != check.prod {
+			t.Errorf("%#02x * %#02x = %#02x; got %#02x", check.a, check.b, check.prod, actual)
+		}
+
+		actual = field.MulByte(check.b, check.a)
+		if actual != check.prod {
+			t.Errorf("%#02x * %#02x = %#02x; got %#02x", check.b, check.a, check.prod, actual)
+		}
+	}
+}
+
+func TestInverseByte(t *testing.T) {
+	field := GF256{}
+
+	if field.InverseByte(0x53) != 0xca {
+		t.Errorf("0x53^-1 = 0xca; got %#02x", field.InverseByte(0x53))
+	}
+
+	if field.InverseByte(0x00) != 0x00 {
+		t.Errorf("Expected 0^-1 to be 0 by convention; got %#02x", field.InverseByte(0x00))
+	}
+
+	for a := 1; a < 256; a++ {
+		inv := field.InverseByte(byte(a))
+		if field.MulByte(byte(a), inv) != 1 {
+			t.Errorf("%#02x * %#02x = 1; got %#02x", a, inv, field.MulByte(byte(a), inv))
+		}
+	}
+}
+
+func TestDivByte(t *testing.T) {
+	field := GF256{}
+
+	for a := 0; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			quot := field.DivByte(byte(a), byte(b))
+			if field.MulByte(quot, byte(b)) != byte(a) {
+				t.Fatalf("(%#02x / %#02x) * %#02x = %#02x; got %#02x", a, b, b, a, field.MulByte(quot, byte(b)))
+			}
+		}
+	}
+}
+
+func TestGF256Arithmetic(t *testing.T) {
+	field := GF256{}
+
+	checks := []struct {
+		a    int64
+		b    int64
+		sum  int64
+		prod int64
+		quot int64
+	}{
+		{0x57, 0x83, 0xd4, 0xc1, 0x38},
+		{0x53, 0x01, 0x52, 0x53, 0x53},
+		{0x00, 0x13, 0x13, 0x00, 0x00},
+	}
+
+	for _, check := range checks {
+		a := big.NewInt(check.a)
+		b := big.NewInt(check.b)
+
+		if actual := field.Add(a, b); actual.Cmp(big.NewInt(check.sum)) != 0 {
+			t.Errorf("%d + %d = %d; got %d", check.a, check.b, check.sum, actual)
+		}
+		if actual := field.Sub(a, b); actual.Cmp(big.NewInt(check.sum)) != 0 {
+			t.Errorf("%d - %d = %d; got %d", check.a, check.b, check.sum, actual)
+		}
+		if actual := field.Mul(a, b); actual.Cmp(big.NewInt(check.prod)) != 0 {
+			t.Errorf("%d * %d = %d; got %d", check.a, check.b, check.prod, actual)
+		}
+		if actual := field.Div(a, b); actual.Cmp(big.NewInt(check.quot)) != 0 {
+			t.Errorf("%d / %d = %d; got %d", check.a, check.b, check.quot, actual)
+		}
+	}
+}
+
+func TestGF256Exp(t *testing.T) {
+	field := GF256{}
+
+	checks := []struct {
+		b   int64
+		e   int64
+		pow int64
+	}{
+		{0x03, 0, 0x01},
+		{0x00, 0, 0x01},
+		{0x00, 5, 0x00},
+		{0x03, 1, 0x03},
+		{0x03, 2, 0x05},
+		{0x03, 255, 0x01},
+		{0x53, 254, 0xca},
+		{0x53, -1, 0xca},
+	}
+
+	for _, check := range checks {
+		actual := field.Exp(big.NewInt(check.b), big.NewInt(check.e))
+		if actual.Cmp(big.NewInt(check.pow)) != 0 {
+			t.Errorf("%d^%d = %d; got %d", check.b, check.e, check.pow, actual)
+		}
+	}
+}
+
+func TestGF256MultInverse(t *testing.T) {
+	field := GF256{}
+
+	actual := field.MultInverse(big.NewInt(0x53))
+	if actual.Cmp(big.NewInt(0xca)) != 0 {
+		t.Errorf("%d^-1 = %d; got %d", 0x53, 0xca, actual)
+	}
+}
+
+func TestGF256Rand(t *testing.T) {
+	field := GF256{}
+
+	for i := 0; i < 10; i++ {
+		rnd, err := field.Rand()
+		if err != nil {
+			t.Errorf("Rand() returned error: %v", err)
+		}
+
+		if !field.IsGroupElement(rnd) {
+			t.Errorf("Rand() = %d; Not valid for GF(2^8)", rnd)
+		}
+	}
+}
+
+func TestGF256IsGroupElement(t *testing.T) {
+	field := GF256{}
+
+	valid := []int64{0, 2, 128, 255}
+	for _, x := range valid {
+		if !field.IsGroupElement(big.NewInt(x)) {
+			t.Errorf("Expected %d to be group element; was not", x)
+		}
+	}
+
+	invalid := []int64{-3, 256, 1024}
+	for _, x := range invalid {
+		if field.IsGroupElement(big.NewInt(x)) {
+			t.Errorf("Expected %d to not be group element; but was", x)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gf
 
 import (
+	"fmt"
 	"math/big"
 )
 
@@ -16,7 +17,7 @@ import (
 // at x = 0, which is all we need for retrieving the secret. It has the benefit
 // that each evaluate of a base polynomial is a scalar rather than a
 // polynomial.
-func BasePolynomial(j int, xs []*big.Int, field GF) *big.Int {
+func BasePolynomial(j int, xs []*big.Int, field Field) *big.Int {
 	// We'll start with a `1` as it's the identity value of multiplication
 	out := big.NewInt(1)
 	xj := xs[j]
@@ -35,3 +36,180 @@ func BasePolynomial(j int, xs []*big.Int, field GF) *big.Int {
 
 	return out
 }
+
+// BasePolynomialAt calculates the Lagrange base polynomial `l_j` at an
+// arbitrary position `x`, ie `l_j(x)`.
+//
+// `l_j(x) = Product for m = 0 to k, where m != j [ (x - x_m) / (x_j - x_m) ]`
+//
+// This allows evaluating the interpolated polynomial at positions other than
+// 0, eg. to calculate the share of a new party.
+func BasePolynomialAt(j int, xs []*big.Int, x *big.Int, field Field) *big.Int {
+	// We'll start with a `1` as it's the identity value of multiplication
+	out := big.NewInt(1)
+	xj := xs[j]
+
+	for i := 0; i < len(xs); i++ {
+		if i == j {
+			continue
+		}
+
+		num := field.Sub(x, xs[i])  // x - x_i
+		den := field.Sub(xj, xs[i]) // x_j - x_i
+		term := field.Div(num, den) // (x - x_i) / (x_j - x_i)
+
+		out = field.Mul(out, term)
+	}
+
+	return out
+}
+
+// LagrangeCoefficients calculates the Lagrange coefficients `l_j(x)` of all
+// base polynomials defined by the points `xs`, at position `x`.
+//
+// The value of the polynomial interpolating the points `(xs, ys)` at position
+// `x` is then `Sum for j [ y_j * l_j(x) ]`.
+//
+// Returns an error if `x` or any of `xs` is not a valid group element, or if
+// `xs` are not unique.
+func LagrangeCoefficients(xs []*big.Int, x *big.Int, field Field) ([]*big.Int, error) {
+	coefs := make([]*big.Int, len(xs))
+
+	if err := checkInterpolationPoints(xs, field); err != nil {
+		return coefs, err
+	}
+
+	if !field.IsGroupElement(x) {
+		return coefs, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	for j := range xs {
+		coefs[j] = BasePolynomialAt(j, xs, x, field)
+	}
+
+	return coefs, nil
+}
+
+// Interpolate evaluates the polynomial interpolating the points `(xs, ys)` at
+// position `x`.
+//
+// Returns an error if the number of x and y values differs, if any of the
+// values is not a valid group element, or if `xs` are not unique.
+func Interpolate(xs []*big.Int, ys []*big.Int, x *big.Int, field Field) (*big.Int, error) {
+	var sum = &big.Int{}
+
+	if len(xs) != len(ys) {
+		return sum, fmt.Errorf("Got %d x values but %d y values", len(xs), len(ys))
+	}
+
+	coefs, err := LagrangeCoefficients(xs, x, field)
+	if err != nil {
+		return sum, err
+	}
+
+	for j, y := range ys {
+		if y == nil || !field.IsGroupElement(y) {
+			return sum, fmt.Errorf("%w: %d", ErrNotInField, y)
+		}
+
+		term := field.Mul(y, coefs[j]) // y_j * l_j(x)
+		sum = field.Add(sum, term)
+	}
+
+	return sum, nil
+}
+
+// InterpolatePolynomial calculates the coefficients of the unique polynomial
+// of degree at most `len(xs) - 1` passing through the points `(xs, ys)`.
+//
+// The polynomial is `Sum for j [ y_j * l_j(x) ]`. The numerator of each base
+// polynomial `l_j` is obtained by dividing the product of all `(x - x_m)` by
+// `(x - x_j)`, which is cheap as the divisor is monic and linear.
+//
+// Returns an error if the number of x and y values differs, if any of the
+// values is not a valid group element, or if `xs` are not unique.
+func InterpolatePolynomial(xs []*big.Int, ys []*big.Int, field Field) (Polynomial, error) {
+	poly := Polynomial{Field: field}
+
+	if len(xs) != len(ys) {
+		return poly, fmt.Errorf("Got %d x values but %d y values", len(xs), len(ys))
+	}
+
+	if len(xs) == 0 {
+		return poly, fmt.Errorf("At least one point required")
+	}
+
+	if err := checkInterpolationPoints(xs, field); err != nil {
+		return poly, err
+	}
+
+	for _, y := range ys {
+		if y == nil || !field.IsGroupElement(y) {
+			return poly, fmt.Errorf("%w: %d", ErrNotInField, y)
+		}
+	}
+
+	// Product of all (x - x_m), with coefficients from lowest to highest
+	// degree.
+	product := []*big.Int{big.NewInt(1)}
+	for _, xm := range xs {
+		next := make([]*big.Int, len(product)+1)
+		for i := range next {
+			next[i] = big.NewInt(0)
+		}
+
+		for i, c := range product {
+			next[i+1] = field.Add(next[i+1], c)            // c * x
+			next[i] = field.Sub(next[i], field.Mul(c, xm)) // - c * x_m
+		}
+		product = next
+	}
+
+	poly.Coefficients = make([]*big.Int, len(xs))
+	for i := range poly.Coefficients {
+		poly.Coefficients[i] = big.NewInt(0)
+	}
+
+	for j, xj := range xs {
+		// Synthetic division of the product by (x - x_j), yielding
+		// the numerator of l_j.
+		num := make([]*big.Int, len(xs))
+		carry := big.NewInt(0)
+		for i := len(xs); i > 0; i-- {
+			carry = field.Add(product[i], field.Mul(carry, xj))
+			num[i-1] = carry
+		}
+
+		// Denominator of l_j is the numerator evaluated at x_j
+		den := big.NewInt(0)
+		for i := len(num) - 1; i >= 0; i-- {
+			den = field.Add(field.Mul(den, xj), num[i])
+		}
+
+		scale := field.Div(ys[j], den) // y_j / Product [ x_j - x_m ]
+		for i, c := range num {
+			poly.Coefficients[i] = field.Add(poly.Coefficients[i], field.Mul(c, scale))
+		}
+	}
+
+	return poly, nil
+}
+
+// checkInterpolationPoints verifies that the points `xs` are unique, valid
+// group elements.
+func checkInterpolationPoints(xs []*big.Int, field Field) error {
+	seen := make(map[string]bool)
+	for _, x := range xs {
+		if x == nil || !field.IsGroupElement(x) {
+			return fmt.Errorf("%w: %d", ErrNotInField, x)
+		}
+
+		key := x.String()
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w %d supplied", ErrDuplicatePoint, x)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package gf
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 )
@@ -31,3 +32,169 @@ func TestBasePolynomial(t *testing.T) {
 		t.Errorf("Expected l_2(0) = 7; got %d", actual)
 	}
 }
+
+func TestBasePolynomialAt(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+	xs := []*big.Int{
+		big.NewInt(1),
+		big.NewInt(3),
+		big.NewInt(5),
+	}
+
+	// At x = 0 it matches BasePolynomial
+	for j := range xs {
+		expected := BasePolynomial(j, xs, gf)
+		actual := BasePolynomialAt(j, xs, big.NewInt(0), gf)
+		if actual.Cmp(expected) != 0 {
+			t.Errorf("Expected l_%d(0) = %d; got %d", j, expected, actual)
+		}
+	}
+
+	// l_j(x_m) = 1 if j = m, 0 otherwise
+	for j := range xs {
+		for m, x := range xs {
+			expected := big.NewInt(0)
+			if j == m {
+				expected = big.NewInt(1)
+			}
+
+			actual := BasePolynomialAt(j, xs, x, gf)
+			if actual.Cmp(expected) != 0 {
+				t.Errorf("Expected l_%d(%d) = %d; got %d", j, x, expected, actual)
+			}
+		}
+	}
+
+	// l_0(2) = (2 - 3)(2 - 5) / ((1 - 3)(1 - 5)) = 3 / 8 = 7 mod 53
+	actual := BasePolynomialAt(0, xs, big.NewInt(2), gf)
+	if actual.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("Expected l_0(2) = 7; got %d", actual)
+	}
+}
+
+func TestLagrangeCoefficients(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+	xs := []*big.Int{
+		big.NewInt(1),
+		big.NewInt(3),
+		big.NewInt(5),
+	}
+
+	coefs, err := LagrangeCoefficients(xs, big.NewInt(0), gf)
+	if err != nil {
+		t.Fatalf("Error calculating Lagrange coefficients: %v", err)
+	}
+	for j, expected := range []int64{35, 12, 7} {
+		if coefs[j].Cmp(big.NewInt(expected)) != 0 {
+			t.Errorf("Expected l_%d(0) = %d; got %d", j, expected, coefs[j])
+		}
+	}
+
+	_, err = LagrangeCoefficients([]*big.Int{big.NewInt(1), big.NewInt(54)}, big.NewInt(0), gf)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if points are not valid group elements; got %v", err)
+	}
+
+	_, err = LagrangeCoefficients([]*big.Int{big.NewInt(1), big.NewInt(1)}, big.NewInt(0), gf)
+	if !errors.Is(err, ErrDuplicatePoint) {
+		t.Errorf("Expected ErrDuplicatePoint if points are not unique; got %v", err)
+	}
+
+	_, err = LagrangeCoefficients(xs, big.NewInt(53), gf)
+	if err == nil {
+		t.Errorf("Expected error if position is not a valid group element; got none")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	// p(x) = 3 + 2x + 5x^2
+	xs := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(5)}
+	ys := []*big.Int{big.NewInt(10), big.NewInt(1), big.NewInt(32)}
+
+	tests := []struct {
+		x        int64
+		expected int64
+	}{
+		{0, 3},
+		{1, 10},
+		{2, 27},
+		{5, 32},
+	}
+
+	for _, test := range tests {
+		actual, err := Interpolate(xs, ys, big.NewInt(test.x), gf)
+		if err != nil {
+			t.Fatalf("Error interpolating: %v", err)
+		}
+
+		if actual.Cmp(big.NewInt(test.expected)) != 0 {
+			t.Errorf("Expected p(%d) = %d; got %d", test.x, test.expected, actual)
+		}
+	}
+
+	_, err = Interpolate(xs, ys[:2], big.NewInt(0), gf)
+	if err == nil {
+		t.Errorf("Expected error if number of x and y values differs; got none")
+	}
+
+	_, err = Interpolate(xs, []*big.Int{big.NewInt(10), big.NewInt(1), big.NewInt(53)}, big.NewInt(0), gf)
+	if err == nil {
+		t.Errorf("Expected error if y values are not valid group elements; got none")
+	}
+}
+
+func TestInterpolatePolynomial(t *testing.T) {
+	gf, err := NewGF(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+
+	// p(x) = 3 + 2x + 5x^2
+	xs := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(5)}
+	ys := []*big.Int{big.NewInt(10), big.NewInt(1), big.NewInt(32)}
+
+	poly, err := InterpolatePolynomial(xs, ys, gf)
+	if err != nil {
+		t.Fatalf("Error interpolating polynomial: %v", err)
+	}
+
+	expected := []int64{3, 2, 5}
+	if poly.Degree() != len(expected)-1 {
+		t.Fatalf("Expected polynomial of degree %d; got %d", len(expected)-1, poly.Degree())
+	}
+	for i, coef := range expected {
+		if poly.Coefficients[i].Cmp(big.NewInt(coef)) != 0 {
+			t.Errorf("Expected coefficient a_%d = %d; got %d", i, coef, poly.Coefficients[i])
+		}
+	}
+
+	// A single point yields a constant polynomial
+	poly, err = InterpolatePolynomial(xs[:1], ys[:1], gf)
+	if err != nil {
+		t.Fatalf("Error interpolating polynomial: %v", err)
+	}
+	if poly.Degree() != 0 || poly.Coefficients[0].Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("Expected p(x) = 10; got %s", poly.String())
+	}
+
+	_, err = InterpolatePolynomial([]*big.Int{}, []*big.Int{}, gf)
+	if err == nil {
+		t.Errorf("Expected error if no points given; got none")
+	}
+
+	_, err = InterpolatePolynomial([]*big.Int{big.NewInt(1), big.NewInt(1)}, ys[:2], gf)
+	if err == nil {
+		t.Errorf("Expected error if points are not unique; got none")
+	}
+}
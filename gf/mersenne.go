@@ -0,0 +1,215 @@
+package gf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// mask63 masks the lower 63 bits of a limb.
+const mask63 = (uint64(1) << 63) - 1
+
+// mersenne127 is the Mersenne prime 2^127 - 1.
+var mersenne127 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+
+// Mersenne127 implements the finite field of prime order 2^127 - 1.
+//
+// Elements are stored in two 64-bit limbs. As `2^127 = 1 mod p`, reduction of
+// a product is a matter of adding its upper bits to its lower 127 bits, rather
+// than a generic division. All arithmetic on limbs is constant-time, which
+// makes inversion slower than the variable-time one of `math/big`.
+type Mersenne127 struct{}
+
+// element127 is an element of `Mersenne127`, as little-endian 64-bit limbs.
+type element127 [2]uint64
+
+// reduce127 reduces a value `v < 2^128` modulo `2^127 - 1`.
+func reduce127(lo uint64, hi uint64) element127 {
+	// v = top * 2^127 + rest = top + rest mod p
+	var carry uint64
+	lo, carry = bits.Add64(lo, hi>>63, 0)
+	hi = (hi & mask63) + carry
+
+	// v <= p + 1 now, so subtract p unless it underflows
+	dlo, borrow := bits.Sub64(lo, ^uint64(0), 0)
+	dhi, borrow := bits.Sub64(hi, mask63, borrow)
+
+	mask := borrow - 1 // All ones if v >= p
+	return element127{
+		lo ^ ((lo ^ dlo) & mask),
+		hi ^ ((hi ^ dhi) & mask),
+	}
+}
+
+// add127 performs addition of two reduced elements.
+func add127(a element127, b element127) element127 {
+	lo, carry := bits.Add64(a[0], b[0], 0)
+	hi, _ := bits.Add64(a[1], b[1], carry) // < 2^128
+
+	return reduce127(lo, hi)
+}
+
+// sub127 performs subtraction of two reduced elements.
+func sub127(a element127, b element127) element127 {
+	// a - b = a + (p - b), where p - b is the bitwise complement of b
+	// within the lower 127 bits.
+	neg := element127{^b[0], ^b[1] & mask63}
+
+	return add127(a, neg)
+}
+
+// mul127 performs multiplication of two reduced elements.
+func mul127(a element127, b element127) element127 {
+	// 256-bit product r = a * b
+	h00, l00 := bits.Mul64(a[0], b[0])
+	h01, l01 := bits.Mul64(a[0], b[1])
+	h10, l10 := bits.Mul64(a[1], b[0])
+	h11, l11 := bits.Mul64(a[1], b[1])
+
+	r0 := l00
+	r1, c := bits.Add64(h00, l01, 0)
+	r2, c2 := bits.Add64(h01, h10, c)
+	r3 := h11 + c2
+	r1, c = bits.Add64(r1, l10, 0)
+	r2, c = bits.Add64(r2, l11, c)
+	r3 += c
+
+	// r = (r >> 127) * 2^127 + (r mod 2^127) = (r >> 127) + (r mod 2^127)
+	topLo := (r1 >> 63) | (r2 << 1)
+	topHi := (r2 >> 63) | (r3 << 1)
+
+	lo, carry := bits.Add64(r0, topLo, 0)
+	hi, _ := bits.Add64(r1&mask63, topHi, carry) // < 2^128
+
+	return reduce127(lo, hi)
+}
+
+// exp127 performs exponentiation of a reduced element, always performing both
+// a squaring and a multiplication for each bit of the exponent.
+func exp127(b element127, e *big.Int) element127 {
+	size := 128
+	if e.BitLen() > size {
+		size = e.BitLen()
+	}
+
+	out := element127{1, 0}
+	for i := size - 1; i >= 0; i-- {
+		out = mul127(out, out)
+		prod := mul127(out, b)
+
+		mask := -uint64(e.Bit(i))
+		out[0] ^= (out[0] ^ prod[0]) & mask
+		out[1] ^= (out[1] ^ prod[1]) & mask
+	}
+
+	return out
+}
+
+// inverse127 calculates the multiplicative inverse of a reduced element using
+// Fermat's little theorem. The inverse of zero is zero.
+func inverse127(a element127) element127 {
+	return exp127(a, new(big.Int).Sub(mersenne127, big.NewInt(2)))
+}
+
+// to127 converts a value into an element, reducing it first if it is outside
+// of the field.
+func (field Mersenne127) to127(x *big.Int) element127 {
+	if !field.IsGroupElement(x) {
+		x = new(big.Int).Mod(x, mersenne127)
+	}
+
+	var a element127
+	fillLimbs(a[:], x)
+
+	return a
+}
+
+// from127 converts an element into its value.
+func from127(a element127) *big.Int {
+	return fromLimbs(a[:])
+}
+
+// Add performs addition in the finite field `field`.
+func (field Mersenne127) Add(a *big.Int, b *big.Int) *big.Int {
+	return from127(add127(field.to127(a), field.to127(b)))
+}
+
+// Sub performs subtraction in the finite field `field`.
+func (field Mersenne127) Sub(a *big.Int, b *big.Int) *big.Int {
+	return from127(sub127(field.to127(a), field.to127(b)))
+}
+
+// Mul performs multiplication in the finite field `field`.
+func (field Mersenne127) Mul(a *big.Int, b *big.Int) *big.Int {
+	return from127(mul127(field.to127(a), field.to127(b)))
+}
+
+// Div performs division in the finite field `field`.
+func (field Mersenne127) Div(a *big.Int, b *big.Int) *big.Int {
+	return from127(mul127(field.to127(a), inverse127(field.to127(b))))
+}
+
+// Exp performs exponentiation in the finite field `field`.
+func (field Mersenne127) Exp(b *big.Int, e *big.Int) *big.Int {
+	base := field.to127(b)
+	if e.Sign() < 0 {
+		base = inverse127(base)
+		e = new(big.Int).Neg(e)
+	}
+
+	return from127(exp127(base, e))
+}
+
+// MultInverse calculates the multiplicative inverse in the finite field
+// `field`.
+func (field Mersenne127) MultInverse(a *big.Int) *big.Int {
+	return from127(inverse127(field.to127(a)))
+}
+
+// Rand returns a random member of the finite field `field`.
+func (field Mersenne127) Rand() (*big.Int, error) {
+	return rand.Int(rand.Reader, mersenne127)
+}
+
+// IsGroupElement checks if a value is an element of the finite field `field`.
+func (field Mersenne127) IsGroupElement(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(mersenne127) == -1
+}
+
+// Order returns the number of elements of the finite field `field`.
+func (field Mersenne127) Order() *big.Int {
+	return new(big.Int).Set(mersenne127)
+}
+
+// ElementSize returns the length of an encoded element of the finite field
+// `field` in bytes.
+func (field Mersenne127) ElementSize() int {
+	return 16
+}
+
+// Encode returns the fixed-width, big-endian encoding of an element of the
+// finite field `field`.
+func (field Mersenne127) Encode(x *big.Int) []byte {
+	return from127(field.to127(x)).FillBytes(make([]byte, field.ElementSize()))
+}
+
+// Decode parses an element of the finite field `field` previously encoded
+// with Encode.
+//
+// Returns an error if the encoding is of invalid length, or does not represent
+// an element of the field.
+func (field Mersenne127) Decode(b []byte) (*big.Int, error) {
+	var x = &big.Int{}
+
+	if len(b) != field.ElementSize() {
+		return x, fmt.Errorf("Encoded element must be %d bytes; got %d", field.ElementSize(), len(b))
+	}
+
+	x.SetBytes(b)
+	if !field.IsGroupElement(x) {
+		return x, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	return x, nil
+}
@@ -0,0 +1,141 @@
+package gf
+
+import (
+	"math/big"
+	"testing"
+)
+
+// checkFieldMatchesGF verifies that all operations of `field` agree with the
+// ones of `GF` of the same order, for edge cases and random values.
+func checkFieldMatchesGF(t *testing.T, field Field) {
+	gf := GF{P: field.Order()}
+	p := gf.P
+
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(p, big.NewInt(1)),
+		new(big.Int).Sub(p, big.NewInt(2)),
+	}
+	for i := 0; i < 20; i++ {
+		rnd, err := field.Rand()
+		if err != nil {
+			t.Fatalf("Error generating random element: %v", err)
+		}
+		values = append(values, rnd)
+	}
+
+	for _, a := range values {
+		for _, b := range values {
+			checks := []struct {
+				op       string
+				expected *big.Int
+				actual   *big.Int
+			}{
+				{"+", gf.Add(a, b), field.Add(a, b)},
+				{"-", gf.Sub(a, b), field.Sub(a, b)},
+				{"*", gf.Mul(a, b), field.Mul(a, b)},
+			}
+
+			if b.Sign() != 0 {
+				checks = append(checks, struct {
+					op       string
+					expected *big.Int
+					actual   *big.Int
+				}{"/", gf.Div(a, b), field.Div(a, b)})
+			}
+
+			for _, check := range checks {
+				if check.expected.Cmp(check.actual) != 0 {
+					t.Errorf("Expected %d %s %d = %d in GF(%d); got %d", a, check.op, b, check.expected, p, check.actual)
+				}
+			}
+		}
+
+		e := values[len(values)-1]
+		if expected, actual := gf.Exp(a, e), field.Exp(a, e); expected.Cmp(actual) != 0 {
+			t.Errorf("Expected %d ^ %d = %d in GF(%d); got %d", a, e, expected, p, actual)
+		}
+
+		if a.Sign() != 0 {
+			if expected, actual := gf.MultInverse(a), field.MultInverse(a); expected.Cmp(actual) != 0 {
+				t.Errorf("Expected %d^-1 = %d in GF(%d); got %d", a, expected, p, actual)
+			}
+		}
+
+		decoded, err := field.Decode(field.Encode(a))
+		if err != nil || decoded.Cmp(a) != 0 {
+			t.Errorf("Expected encoding of %d to round trip; got %d, error %v", a, decoded, err)
+		}
+	}
+
+	// Unreduced inputs
+	if expected, actual := gf.Add(big.NewInt(-3), p), field.Add(big.NewInt(-3), p); expected.Cmp(actual) != 0 {
+		t.Errorf("Expected -3 + p = %d in GF(%d); got %d", expected, p, actual)
+	}
+
+	if expected, actual := gf.Exp(big.NewInt(2), big.NewInt(-1)), field.Exp(big.NewInt(2), big.NewInt(-1)); expected.Cmp(actual) != 0 {
+		t.Errorf("Expected 2^-1 = %d in GF(%d); got %d", expected, p, actual)
+	}
+
+	if field.IsGroupElement(p) || field.IsGroupElement(big.NewInt(-1)) {
+		t.Errorf("Expected -1 and p not to be group elements of GF(%d)", p)
+	}
+
+	if _, err := field.Decode(make([]byte, field.ElementSize()+1)); err == nil {
+		t.Errorf("Expected error if encoding is of invalid length; got none")
+	}
+}
+
+func TestMersenne127(t *testing.T) {
+	field := Mersenne127{}
+
+	if field.Order().Cmp(mersenne127Field().P) != 0 {
+		t.Errorf("Expected order 2^127 - 1; got %d", field.Order())
+	}
+
+	if field.ElementSize() != 16 {
+		t.Errorf("Expected element size of 16 bytes; got %d", field.ElementSize())
+	}
+
+	checkFieldMatchesGF(t, field)
+}
+
+func BenchmarkMersenne127Mul(b *testing.B) {
+	field := Mersenne127{}
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Mul(x, y)
+	}
+}
+
+func BenchmarkMersenne127MulBig(b *testing.B) {
+	field := mersenne127Field()
+	x, _ := field.Rand()
+	y, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.Mul(x, y)
+	}
+}
+
+func BenchmarkMersenne127Inverse(b *testing.B) {
+	field := Mersenne127{}
+	x, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.MultInverse(x)
+	}
+}
+
+func BenchmarkMersenne127InverseBig(b *testing.B) {
+	field := mersenne127Field()
+	x, _ := field.Rand()
+
+	for i := 0; i < b.N; i++ {
+		field.MultInverse(x)
+	}
+}
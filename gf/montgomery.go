@@ -0,0 +1,374 @@
+package gf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Element is an element of a `Montgomery` field, in Montgomery form and
+// stored as little-endian 64-bit limbs.
+//
+// All elements of a field have the same number of limbs, such that the time
+// operations take does not depend on their values.
+type Element []uint64
+
+// Montgomery implements a finite field of odd prime order with constant-time
+// arithmetic.
+//
+// Unlike `GF`, which relies on `math/big` and hence leaks timing information
+// about the values it operates on, elements are stored in Montgomery form
+// `a * R mod p`, with `R = 2^(64 n)` for `n` limbs, and multiplied using
+// Montgomery reduction. No operation branches on or indexes memory by secret
+// values.
+//
+// The methods operating on `Element` values are constant-time. The methods
+// implementing `Field` allow using the field with `Polynomial` and package
+// `secretshare`, and convert from and to `*big.Int` at their boundary. The
+// conversion always processes the full number of limbs, and reduces values
+// below `R` without branching. As `math/big` strips leading zero words and
+// allocates accordingly, the conversion may still leak the number of non-zero
+// words of a value, but no other information about it. Values which are
+// negative or of more than n limbs are reduced using `math/big`.
+type Montgomery struct {
+	p     Element  // Modulus, in regular form
+	pInv  uint64   // -p^{-1} mod 2^64
+	rr    Element  // R^2 mod p, to convert to Montgomery form
+	one   Element  // R mod p, ie 1 in Montgomery form
+	order *big.Int // Modulus
+}
+
+// NewMontgomery creates a new finite field of prime order with
+// constant-time arithmetic.
+//
+// Returns an error if the order is not an odd prime.
+func NewMontgomery(order *big.Int) (Montgomery, error) {
+	var field Montgomery
+
+	if order.Bit(0) == 0 || !order.ProbablyPrime(20) {
+		return field, fmt.Errorf("Field must be of odd prime order; %d is not", order)
+	}
+
+	n := (order.BitLen() + 63) / 64
+	field.order = new(big.Int).Set(order)
+	field.p = limbs(order, n)
+
+	// Newton's iteration doubles the number of correct low bits of the
+	// inverse with each step, starting at 1 bit as p is odd.
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - field.p[0]*inv
+	}
+	field.pInv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*n))
+	field.one = limbs(new(big.Int).Mod(r, order), n)
+	field.rr = limbs(new(big.Int).Exp(r, big.NewInt(2), order), n)
+
+	return field, nil
+}
+
+// limbs returns the little-endian 64-bit limbs of a non-negative value, padded
+// to `n` limbs.
+func limbs(x *big.Int, n int) Element {
+	out := make(Element, n)
+	fillLimbs(out, x)
+
+	return out
+}
+
+// fillLimbs sets `dst` to the little-endian 64-bit limbs of a non-negative
+// value, which must fit into `dst`.
+//
+// The words of the value are copied into a buffer of fixed width first, such
+// that all limbs are always processed.
+func fillLimbs(dst []uint64, x *big.Int) {
+	// Words of a big.Int are 32 or 64 bits wide, depending on the platform
+	perLimb := 64 / bits.UintSize
+	words := make([]big.Word, len(dst)*perLimb)
+	copy(words, x.Bits())
+
+	for i := range dst {
+		dst[i] = 0
+	}
+	for i, word := range words {
+		dst[i/perLimb] |= uint64(word) << uint(bits.UintSize*(i%perLimb))
+	}
+}
+
+// fromLimbs returns the value of little-endian 64-bit limbs.
+func fromLimbs(a []uint64) *big.Int {
+	perLimb := 64 / bits.UintSize
+	words := make([]big.Word, len(a)*perLimb)
+	for i := range words {
+		words[i] = big.Word(a[i/perLimb] >> uint(bits.UintSize*(i%perLimb)))
+	}
+
+	return new(big.Int).SetBits(words)
+}
+
+// FromBig converts a value into an element of the field in Montgomery form.
+//
+// Values below `R` are reduced by the Montgomery multiplication, as
+// `x * R^2 < R p`, without branching on the value. Negative values and values
+// of more than n limbs are reduced modulo the order of the field first.
+func (field Montgomery) FromBig(x *big.Int) Element {
+	if x.Sign() < 0 || x.BitLen() > 64*len(field.p) {
+		x = new(big.Int).Mod(x, field.order)
+	}
+
+	return field.MulElement(limbs(x, len(field.p)), field.rr) // x * R^2 / R
+}
+
+// ToBig converts an element of the field from Montgomery form into its value.
+func (field Montgomery) ToBig(a Element) *big.Int {
+	unit := make(Element, len(field.p))
+	unit[0] = 1
+
+	return fromLimbs(field.MulElement(a, unit)) // a R * 1 / R
+}
+
+// AddElement performs addition of two elements in constant time.
+func (field Montgomery) AddElement(a Element, b Element) Element {
+	n := len(field.p)
+	sum := make(Element, n)
+	diff := make(Element, n)
+
+	var carry, borrow uint64
+	for i := 0; i < n; i++ {
+		sum[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	for i := 0; i < n; i++ {
+		diff[i], borrow = bits.Sub64(sum[i], field.p[i], borrow)
+	}
+
+	// Keep a + b - p unless it underflowed, including the carry out
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	selectElement(borrow, sum, diff)
+
+	return diff
+}
+
+// SubElement performs subtraction of two elements in constant time.
+func (field Montgomery) SubElement(a Element, b Element) Element {
+	n := len(field.p)
+	diff := make(Element, n)
+
+	var borrow, carry uint64
+	for i := 0; i < n; i++ {
+		diff[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+
+	// Add p back if a - b underflowed
+	mask := -borrow
+	for i := 0; i < n; i++ {
+		diff[i], carry = bits.Add64(diff[i], field.p[i]&mask, carry)
+	}
+
+	return diff
+}
+
+// MulElement performs multiplication of two elements in Montgomery form in
+// constant time, returning `a * b / R mod p`.
+//
+// This is the coarsely integrated operand scanning (CIOS) method, which
+// interleaves multiplication and reduction limb by limb.
+func (field Montgomery) MulElement(a Element, b Element) Element {
+	n := len(field.p)
+	t := make([]uint64, n+2)
+
+	for i := 0; i < n; i++ {
+		// t += a * b_i
+		var c uint64
+		for j := 0; j < n; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var carry uint64
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+		var carry uint64
+		t[n], carry = bits.Add64(t[n], c, 0)
+		t[n+1] = carry
+
+		// t = (t + m p) / 2^64, with m chosen such that the lowest
+		// limb vanishes
+		m := t[0] * field.pInv
+		hi, lo := bits.Mul64(m, field.p[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, field.p[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+		t[n-1], carry = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + carry
+	}
+
+	// t < 2p, so a single conditional subtraction fully reduces it
+	out := make(Element, n)
+	var borrow uint64
+	for i := 0; i < n; i++ {
+		out[i], borrow = bits.Sub64(t[i], field.p[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[n], 0, borrow)
+	selectElement(borrow, t[:n], out)
+
+	return out
+}
+
+// ExpElement performs exponentiation of an element in constant time.
+//
+// The exponent is processed bit by bit, always performing both a squaring and
+// a multiplication, and only its length may be inferred from timing. Negative
+// exponents exponentiate the inverse of `b`.
+func (field Montgomery) ExpElement(b Element, e *big.Int) Element {
+	if e.Sign() < 0 {
+		b = field.InverseElement(b)
+		e = new(big.Int).Neg(e)
+	}
+
+	// Pad the exponent to at least the size of the modulus, such that
+	// small exponents are not distinguishable from large ones.
+	n := len(field.p)
+	if words := (e.BitLen() + 63) / 64; words > n {
+		n = words
+	}
+	exp := limbs(e, n)
+
+	out := make(Element, len(field.p))
+	copy(out, field.one)
+	for i := 64*n - 1; i >= 0; i-- {
+		out = field.MulElement(out, out)
+		prod := field.MulElement(out, b)
+
+		bit := (exp[i/64] >> uint(i%64)) & 1
+		selectElement(bit, prod, out)
+	}
+
+	return out
+}
+
+// InverseElement calculates the multiplicative inverse of an element in
+// constant time, using Fermat's little theorem: `a^{-1} = a^{p-2}`.
+//
+// The inverse of zero is zero.
+func (field Montgomery) InverseElement(a Element) Element {
+	exp := new(big.Int).Sub(field.order, big.NewInt(2))
+
+	return field.ExpElement(a, exp)
+}
+
+// DivElement performs division of two elements in constant time.
+func (field Montgomery) DivElement(a Element, b Element) Element {
+	return field.MulElement(a, field.InverseElement(b))
+}
+
+// EqualElement checks whether two elements are equal in constant time.
+func (field Montgomery) EqualElement(a Element, b Element) bool {
+	var acc uint64
+	for i := range a {
+		acc |= a[i] ^ b[i]
+	}
+
+	// The top bit of acc | -acc is set for any non-zero acc
+	return (acc|-acc)>>63 == 0
+}
+
+// selectElement sets `dst` to `src` if `cond` is 1, and leaves it unchanged if
+// `cond` is 0, without branching.
+func selectElement(cond uint64, src Element, dst Element) {
+	mask := -cond
+	for i := range dst {
+		dst[i] ^= (dst[i] ^ src[i]) & mask
+	}
+}
+
+// Add performs addition in the finite field `field`.
+func (field Montgomery) Add(a *big.Int, b *big.Int) *big.Int {
+	return field.ToBig(field.AddElement(field.FromBig(a), field.FromBig(b)))
+}
+
+// Sub performs subtraction in the finite field `field`.
+func (field Montgomery) Sub(a *big.Int, b *big.Int) *big.Int {
+	return field.ToBig(field.SubElement(field.FromBig(a), field.FromBig(b)))
+}
+
+// Mul performs multiplication in the finite field `field`.
+func (field Montgomery) Mul(a *big.Int, b *big.Int) *big.Int {
+	return field.ToBig(field.MulElement(field.FromBig(a), field.FromBig(b)))
+}
+
+// Div performs division in the finite field `field`.
+func (field Montgomery) Div(a *big.Int, b *big.Int) *big.Int {
+	return field.ToBig(field.DivElement(field.FromBig(a), field.FromBig(b)))
+}
+
+// Exp performs exponentiation in the finite field `field`.
+func (field Montgomery) Exp(b *big.Int, e *big.Int) *big.Int {
+	return field.ToBig(field.ExpElement(field.FromBig(b), e))
+}
+
+// MultInverse calculates the multiplicative inverse in the finite field
+// `field`.
+func (field Montgomery) MultInverse(a *big.Int) *big.Int {
+	return field.ToBig(field.InverseElement(field.FromBig(a)))
+}
+
+// Rand returns a random member of the finite field `field`.
+func (field Montgomery) Rand() (*big.Int, error) {
+	return rand.Int(rand.Reader, field.order)
+}
+
+// IsGroupElement checks if a value is an element of the finite field `field`.
+func (field Montgomery) IsGroupElement(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(field.order) == -1
+}
+
+// Order returns the number of elements of the finite field `field`.
+func (field Montgomery) Order() *big.Int {
+	return field.order
+}
+
+// ElementSize returns the length of an encoded element of the finite field
+// `field` in bytes.
+func (field Montgomery) ElementSize() int {
+	return (field.order.BitLen() + 7) / 8
+}
+
+// Encode returns the fixed-width, big-endian encoding of an element of the
+// finite field `field`.
+func (field Montgomery) Encode(x *big.Int) []byte {
+	if !field.IsGroupElement(x) {
+		x = new(big.Int).Mod(x, field.order)
+	}
+
+	return x.FillBytes(make([]byte, field.ElementSize()))
+}
+
+// Decode parses an element of the finite field `field` previously encoded
+// with Encode.
+//
+// Returns an error if the encoding is of invalid length, or does not represent
+// an element of the field.
+func (field Montgomery) Decode(b []byte) (*big.Int, error) {
+	var x = &big.Int{}
+
+	if len(b) != field.ElementSize() {
+		return x, fmt.Errorf("Encoded element must be %d bytes; got %d", field.ElementSize(), len(b))
+	}
+
+	x.SetBytes(b)
+	if !field.IsGroupElement(x) {
+		return x, fmt.Errorf("%w: %d", ErrNotInField, x)
+	}
+
+	return x, nil
+}
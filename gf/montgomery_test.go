@@ -0,0 +1,203 @@
+package gf
+
+import (
+	"math/big"
+	"testing"
+)
+
+// montgomeryTestPrimes returns primes of various sizes, including ones whose
+// top limb has its highest bit set.
+func montgomeryTestPrimes() []*big.Int {
+	p256, _ := new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	p521 := new(big.Int).Lsh(big.NewInt(1), 521)
+	p521.Sub(p521, big.NewInt(1))
+
+	return []*big.Int{
+		big.NewInt(3),
+		big.NewInt(53),
+		new(big.Int).SetUint64(18446744073709551557), // 2^64 - 59
+		mersenne127Field().P,
+		p256,
+		p521,
+	}
+}
+
+func TestNewMontgomery(t *testing.T) {
+	_, err := NewMontgomery(big.NewInt(53))
+	if err != nil {
+		t.Errorf("Expected no error; got '%s'", err)
+	}
+
+	_, err = NewMontgomery(big.NewInt(2))
+	if err == nil {
+		t.Errorf("Expected error for even order; got none")
+	}
+
+	_, err = NewMontgomery(big.NewInt(51))
+	if err == nil {
+		t.Errorf("Expected error for non-prime order; got none")
+	}
+}
+
+func TestMontgomeryMatchesGF(t *testing.T) {
+	for _, p := range montgomeryTestPrimes() {
+		mont, err := NewMontgomery(p)
+		if err != nil {
+			t.Fatalf("Error creating Montgomery field: %v", err)
+		}
+		gf := GF{P: p}
+
+		values := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			new(big.Int).Sub(p, big.NewInt(1)),
+		}
+		for i := 0; i < 20; i++ {
+			rnd, err := gf.Rand()
+			if err != nil {
+				t.Fatalf("Error generating random element: %v", err)
+			}
+			values = append(values, rnd)
+		}
+
+		for _, a := range values {
+			if actual := mont.ToBig(mont.FromBig(a)); actual.Cmp(a) != 0 {
+				t.Errorf("Expected round trip of %d in GF(%d); got %d", a, p, actual)
+			}
+
+			for _, b := range values[len(values)-5:] {
+				checks := []struct {
+					op       string
+					expected *big.Int
+					actual   *big.Int
+				}{
+					{"+", gf.Add(a, b), mont.Add(a, b)},
+					{"-", gf.Sub(a, b), mont.Sub(a, b)},
+					{"*", gf.Mul(a, b), mont.Mul(a, b)},
+					{"^", gf.Exp(a, b), mont.Exp(a, b)},
+				}
+
+				for _, check := range checks {
+					if check.expected.Cmp(check.actual) != 0 {
+						t.Errorf("Expected %d %s %d = %d in GF(%d); got %d", a, check.op, b, check.expected, p, check.actual)
+					}
+				}
+			}
+
+			if a.Sign() != 0 {
+				inv := mont.MultInverse(a)
+				if mont.Mul(a, inv).Cmp(big.NewInt(1)) != 0 {
+					t.Errorf("Expected %d * %d = 1 in GF(%d)", a, inv, p)
+				}
+
+				quot := mont.Div(big.NewInt(1), a)
+				if quot.Cmp(inv) != 0 {
+					t.Errorf("Expected 1 / %d = %d in GF(%d); got %d", a, inv, p, quot)
+				}
+			}
+		}
+	}
+}
+
+func TestMontgomeryUnreducedInputs(t *testing.T) {
+	mont, err := NewMontgomery(big.NewInt(53))
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+
+	if actual := mont.Add(big.NewInt(-3), big.NewInt(100)); actual.Cmp(big.NewInt(44)) != 0 {
+		t.Errorf("Expected -3 + 100 = 44; got %d", actual)
+	}
+
+	if actual := mont.Exp(big.NewInt(2), big.NewInt(-1)); actual.Cmp(big.NewInt(27)) != 0 {
+		t.Errorf("Expected 2^-1 = 27; got %d", actual)
+	}
+
+	if actual := mont.MultInverse(big.NewInt(0)); actual.Sign() != 0 {
+		t.Errorf("Expected inverse of 0 to be 0; got %d", actual)
+	}
+
+	if !mont.EqualElement(mont.FromBig(big.NewInt(7)), mont.FromBig(big.NewInt(60))) {
+		t.Errorf("Expected 7 and 60 to be equal elements")
+	}
+	if mont.EqualElement(mont.FromBig(big.NewInt(7)), mont.FromBig(big.NewInt(8))) {
+		t.Errorf("Expected 7 and 8 to be different elements")
+	}
+
+	// Values below R = 2^64 are reduced by the Montgomery multiplication,
+	// larger ones modulo the order.
+	maxLimb := new(big.Int).SetUint64(^uint64(0))
+	expected := new(big.Int).Mod(maxLimb, big.NewInt(53))
+	if actual := mont.ToBig(mont.FromBig(maxLimb)); actual.Cmp(expected) != 0 {
+		t.Errorf("Expected 2^64 - 1 to be reduced to %d; got %d", expected, actual)
+	}
+
+	beyond := new(big.Int).Lsh(big.NewInt(1), 64)
+	expected = new(big.Int).Mod(beyond, big.NewInt(53))
+	if actual := mont.ToBig(mont.FromBig(beyond)); actual.Cmp(expected) != 0 {
+		t.Errorf("Expected 2^64 to be reduced to %d; got %d", expected, actual)
+	}
+}
+
+func TestMontgomeryEncode(t *testing.T) {
+	mont, err := NewMontgomery(mersenne127Field().P)
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+
+	if mont.ElementSize() != 16 {
+		t.Errorf("Expected element size of 16 bytes; got %d", mont.ElementSize())
+	}
+
+	x := big.NewInt(1234567)
+	decoded, err := mont.Decode(mont.Encode(x))
+	if err != nil {
+		t.Fatalf("Error decoding element: %v", err)
+	}
+	if decoded.Cmp(x) != 0 {
+		t.Errorf("Expected decoded element %d; got %d", x, decoded)
+	}
+
+	_, err = mont.Decode(make([]byte, 15))
+	if err == nil {
+		t.Errorf("Expected error if encoding is of invalid length; got none")
+	}
+}
+
+func TestMontgomeryPolynomial(t *testing.T) {
+	mont, err := NewMontgomery(big.NewInt(17))
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+
+	// p(x) = 15 x^2 + 8x + 3
+	poly := testPolynomial(mont, 3, 8, 15)
+	actual, err := poly.Evaluate(big.NewInt(6))
+	if err != nil {
+		t.Fatalf("Error evaluating polynomial: %v", err)
+	}
+	if actual.Cmp(big.NewInt(13)) != 0 {
+		t.Errorf("Expected p(6) = 13; got %d", actual)
+	}
+}
+
+func BenchmarkMulGF(b *testing.B) {
+	gf := mersenne127Field()
+	x, _ := gf.Rand()
+	y, _ := gf.Rand()
+
+	for i := 0; i < b.N; i++ {
+		gf.Mul(x, y)
+	}
+}
+
+func BenchmarkMulMontgomery(b *testing.B) {
+	mont, _ := NewMontgomery(mersenne127Field().P)
+	x, _ := mont.Rand()
+	y, _ := mont.Rand()
+	a, c := mont.FromBig(x), mont.FromBig(y)
+
+	for i := 0; i < b.N; i++ {
+		mont.MulElement(a, c)
+	}
+}
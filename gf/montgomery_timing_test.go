@@ -0,0 +1,113 @@
+//go:build timing
+// +build timing
+
+// Timing tests compare wall-clock durations, and hence fail at random on busy
+// machines. They only run with the `timing` build tag:
+// `go test -tags timing ./gf`
+
+package gf
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+)
+
+// medianDuration runs `f` in batches and returns the median duration of a
+// batch.
+func medianDuration(f func(), batches int, size int) time.Duration {
+	durations := make([]time.Duration, batches)
+	for i := range durations {
+		start := time.Now()
+		for j := 0; j < size; j++ {
+			f()
+		}
+		durations[i] = time.Since(start)
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[batches/2]
+}
+
+// checkTiming verifies that the median durations of batches of `size` runs of
+// two operations do not differ by more than `tolerance`, retrying a few times
+// to account for noise.
+func checkTiming(t *testing.T, name string, f0 func(), f1 func(), size int, tolerance float64) {
+	var ratio float64
+	for attempt := 0; attempt < 5; attempt++ {
+		d0 := medianDuration(f0, 101, size)
+		d1 := medianDuration(f1, 101, size)
+
+		ratio = float64(d0) / float64(d1)
+		if ratio < 1 {
+			ratio = 1 / ratio
+		}
+		if ratio < 1+tolerance {
+			return
+		}
+	}
+
+	t.Errorf("Expected timing of %s to be independent of operands; differs by factor %.2f", name, ratio)
+}
+
+func TestMontgomeryConstantTime(t *testing.T) {
+	p256, _ := new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	mont, err := NewMontgomery(p256)
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+
+	zero := mont.FromBig(big.NewInt(0))
+	max := mont.FromBig(new(big.Int).Sub(p256, big.NewInt(1)))
+	rnd, _ := mont.Rand()
+	random := mont.FromBig(rnd)
+
+	checkTiming(t, "multiplication",
+		func() { mont.MulElement(zero, zero) },
+		func() { mont.MulElement(max, random) },
+		200, 0.25,
+	)
+
+	checkTiming(t, "addition",
+		func() { mont.AddElement(zero, zero) },
+		func() { mont.AddElement(max, max) },
+		200, 0.25,
+	)
+
+	checkTiming(t, "inversion",
+		func() { mont.InverseElement(mont.FromBig(big.NewInt(1))) },
+		func() { mont.InverseElement(random) },
+		2, 0.25,
+	)
+
+	one := big.NewInt(1)
+	ones := new(big.Int).Sub(new(big.Int).Lsh(one, 256), one)
+	checkTiming(t, "exponentiation",
+		func() { mont.ExpElement(random, one) },
+		func() { mont.ExpElement(random, ones) },
+		2, 0.25,
+	)
+
+	// The methods implementing Field, which Polynomial and package
+	// secretshare use, convert at their boundary with fixed width.
+	small := big.NewInt(1)
+	large := new(big.Int).Sub(p256, big.NewInt(1))
+	checkTiming(t, "field multiplication",
+		func() { mont.Mul(small, small) },
+		func() { mont.Mul(large, rnd) },
+		100, 0.25,
+	)
+
+	checkTiming(t, "field addition",
+		func() { mont.Add(small, small) },
+		func() { mont.Add(large, large) },
+		100, 0.25,
+	)
+
+	checkTiming(t, "field inversion",
+		func() { mont.MultInverse(small) },
+		func() { mont.MultInverse(rnd) },
+		2, 0.25,
+	)
+}
@@ -3,13 +3,15 @@ package gf
 import (
 	"fmt"
 	"math/big"
+	"runtime"
 	"strings"
+	"sync"
 )
 
 // Polynomial over a finite field
 type Polynomial struct {
 	// Field the polynomial is in
-	Field GF
+	Field Field
 	// Coefficients of the polynomial, ordered from the lowest degree to
 	// the highest
 	Coefficients []*big.Int
@@ -17,7 +19,7 @@ type Polynomial struct {
 
 // NewPolynomial initializes a new polynomial of given degree in the given
 // field.
-func NewPolynomial(degree int, field GF) (Polynomial, error) {
+func NewPolynomial(degree int, field Field) (Polynomial, error) {
 	poly := Polynomial{Field: field}
 
 	if degree < 0 {
@@ -36,27 +38,100 @@ func (pol *Polynomial) Degree() int {
 
 // Evaluate evaluates the polynomial at a given point
 //
+// It uses Horner's method, rewriting the polynomial as
+// `a_0 + x * (a_1 + x * (a_2 + ... + x * a_n))`, which requires only `n`
+// multiplications and additions, rather than an exponentiation per term.
+//
 // Returns an error if the provided value is not a valid group element.
 func (pol *Polynomial) Evaluate(x *big.Int) (*big.Int, error) {
 	var result = &big.Int{}
 
 	if !pol.Field.IsGroupElement(x) {
-		return result, fmt.Errorf("%d is not a valid group element", x)
+		return result, fmt.Errorf("%w: %d", ErrNotInField, x)
 	}
 
-	// Coefficient a_i at index i corresponds to a_i * x^i
-	for exp, coef := range pol.Coefficients {
-		// We'll utilize modular exponentiation for each term of the
-		// sum, to prevent having potentially huge intermediary values
-		term := pol.Field.Exp(x, big.NewInt(int64(exp))) // x^i
-		term = pol.Field.Mul(term, coef)                 // a_i * x^i
-
-		result = pol.Field.Add(result, term) // a_0 + a_1 * x + ... + a_n * x^n
+	for i := pol.Degree(); i >= 0; i-- {
+		result = pol.Field.Mul(result, x)                   // (...) * x
+		result = pol.Field.Add(result, pol.Coefficients[i]) // (...) * x + a_i
 	}
 
 	return result, nil
 }
 
+// EvaluateMany evaluates the polynomial at all given points.
+//
+// Returns a slice containing the value at each point, in the same order.
+// An error is returned if any of the points is not a valid group element.
+func (pol *Polynomial) EvaluateMany(xs []*big.Int) ([]*big.Int, error) {
+	ys := make([]*big.Int, len(xs))
+
+	for i, x := range xs {
+		y, err := pol.Evaluate(x)
+		if err != nil {
+			return ys, err
+		}
+		ys[i] = y
+	}
+
+	return ys, nil
+}
+
+// EvaluateManyConcurrent evaluates the polynomial at all given points like
+// `EvaluateMany`, distributing the points across `workers` goroutines.
+//
+// This pays off for polynomials of high degree or many points. If `workers` is
+// less than 1, one worker per available CPU is used.
+//
+// Returns a slice containing the value at each point, in the same order.
+// An error is returned if any of the points is not a valid group element.
+func (pol *Polynomial) EvaluateManyConcurrent(xs []*big.Int, workers int) ([]*big.Int, error) {
+	ys := make([]*big.Int, len(xs))
+
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(xs) {
+		workers = len(xs)
+	}
+	if workers <= 1 {
+		return pol.EvaluateMany(xs)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	size := (len(xs) + workers - 1) / workers
+	for w := 0; w < workers; w++ {
+		start := w * size
+		end := start + size
+		if end > len(xs) {
+			end = len(xs)
+		}
+
+		wg.Add(1)
+		go func(w int, start int, end int) {
+			defer wg.Done()
+
+			for i := start; i < end; i++ {
+				y, err := pol.Evaluate(xs[i])
+				if err != nil {
+					errs[w] = err
+					return
+				}
+				ys[i] = y
+			}
+		}(w, start, end)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return ys, err
+		}
+	}
+
+	return ys, nil
+}
+
 // Return a string representation of this polynomial for printing purposes.
 func (pol *Polynomial) String() string {
 	var b strings.Builder
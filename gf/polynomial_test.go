@@ -86,6 +86,107 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+func TestEvaluateMany(t *testing.T) {
+	gf, err := NewGF(big.NewInt(17))
+	if err != nil {
+		t.Errorf("Error while creating new GF of prime order: %v", err)
+	}
+	// p(x) = 15 x^2 + 8x + 3
+	poly, _ := NewPolynomial(2, gf)
+	poly.Coefficients[0] = big.NewInt(3)
+	poly.Coefficients[1] = big.NewInt(8)
+	poly.Coefficients[2] = big.NewInt(15)
+
+	xs := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5), big.NewInt(6)}
+	expected := []int64{3, 9, 11, 9, 3, 10, 13}
+
+	for _, workers := range []int{0, 1, 3, 100} {
+		ys, err := poly.EvaluateManyConcurrent(xs, workers)
+		if err != nil {
+			t.Fatalf("Error evaluating polynomial with %d workers: %v", workers, err)
+		}
+
+		for i, y := range expected {
+			if ys[i].Cmp(big.NewInt(y)) != 0 {
+				t.Errorf("Expected p(%d) = %d with %d workers; got %d", xs[i], y, workers, ys[i])
+			}
+		}
+	}
+
+	ys, err := poly.EvaluateMany(xs)
+	if err != nil {
+		t.Fatalf("Error evaluating polynomial: %v", err)
+	}
+	for i, y := range expected {
+		if ys[i].Cmp(big.NewInt(y)) != 0 {
+			t.Errorf("Expected p(%d) = %d; got %d", xs[i], y, ys[i])
+		}
+	}
+
+	invalid := []*big.Int{big.NewInt(1), big.NewInt(20), big.NewInt(2)}
+	_, err = poly.EvaluateMany(invalid)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+
+	_, err = poly.EvaluateManyConcurrent(invalid, 3)
+	if err == nil {
+		t.Error("Expected error, got none")
+	}
+}
+
+// evaluateNaive evaluates a polynomial with one exponentiation per term, for
+// comparison with Horner's method.
+func evaluateNaive(pol Polynomial, x *big.Int) *big.Int {
+	var result = &big.Int{}
+
+	for exp, coef := range pol.Coefficients {
+		term := pol.Field.Exp(x, big.NewInt(int64(exp))) // x^i
+		term = pol.Field.Mul(term, coef)                 // a_i * x^i
+		result = pol.Field.Add(result, term)
+	}
+
+	return result
+}
+
+func benchmarkPolynomial(b *testing.B) (Polynomial, []*big.Int) {
+	poly, err := RandomPolynomial(31, mersenne127Field())
+	if err != nil {
+		b.Fatalf("Error creating random polynomial: %v", err)
+	}
+
+	return poly, benchmarkPoints(255)
+}
+
+func BenchmarkEvaluateNaive(b *testing.B) {
+	poly, xs := benchmarkPolynomial(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, x := range xs {
+			evaluateNaive(poly, x)
+		}
+	}
+}
+
+func BenchmarkEvaluateMany(b *testing.B) {
+	poly, xs := benchmarkPolynomial(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		poly.EvaluateMany(xs)
+	}
+}
+
+func BenchmarkEvaluateManyConcurrent(b *testing.B) {
+	poly, xs := benchmarkPolynomial(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		poly.EvaluateManyConcurrent(xs, 0)
+	}
+}
+
 func TestString(t *testing.T) {
 	gf, err := NewGF(big.NewInt(17))
 	if err != nil {
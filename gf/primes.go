@@ -0,0 +1,245 @@
+package gf
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// modp2048 is the 2048-bit safe prime of the MODP group 14 of RFC 3526.
+const modp2048 = "FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74" +
+	"020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437" +
+	"4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+	"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF05" +
+	"98DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB" +
+	"9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B" +
+	"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF695581718" +
+	"3995497CEA956AE515D2261898FA051015728E5A8AACAA68FFFFFFFFFFFFFFFF"
+
+// namedPrimes is the registry of well-known primes, by name.
+var namedPrimes = map[string]func() *big.Int{
+	// 2^127 - 1
+	"p127": func() *big.Int { return new(big.Int).Set(mersenne127) },
+	// 2^255 - 19, the base field of Curve25519
+	"p255": func() *big.Int { return new(big.Int).Set(Curve25519Base().Order()) },
+	// 2^521 - 1
+	"p521": func() *big.Int {
+		p := new(big.Int).Lsh(big.NewInt(1), 521)
+		return p.Sub(p, big.NewInt(1))
+	},
+	"ed25519":   func() *big.Int { return new(big.Int).Set(Ed25519Order().Order()) },
+	"secp256k1": func() *big.Int { return new(big.Int).Set(Secp256k1Order().Order()) },
+	"p256":      func() *big.Int { return new(big.Int).Set(P256Order().Order()) },
+	"modp2048": func() *big.Int {
+		p, _ := new(big.Int).SetString(modp2048, 16)
+		return p
+	},
+}
+
+// PrimeNames returns the sorted names of all primes in the registry.
+func PrimeNames() []string {
+	names := make([]string, 0, len(namedPrimes))
+	for name := range namedPrimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NamedPrime returns the prime of the given name from the registry.
+//
+// The registry contains the Mersenne primes `p127` and `p521`, the base field
+// of Curve25519 `p255`, the group orders `ed25519`, `secp256k1` and `p256` of
+// common elliptic curves, and the 2048-bit safe prime `modp2048` of RFC 3526.
+//
+// Returns an error if no prime of the given name exists.
+func NamedPrime(name string) (*big.Int, error) {
+	prime, ok := namedPrimes[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown prime '%s'", name)
+	}
+
+	return prime(), nil
+}
+
+// FieldNames returns the sorted names of all fields available through
+// `NamedField`.
+func FieldNames() []string {
+	names := append(PrimeNames(), "gf256")
+	sort.Strings(names)
+
+	return names
+}
+
+// NamedField returns the field of the given name, which is either `gf256` or
+// the prime field of a prime in the registry, see `NamedPrime`.
+//
+// Returns an error if no field of the given name exists.
+func NamedField(name string) (Field, error) {
+	if name == "gf256" {
+		return GF256{}, nil
+	}
+
+	prime, err := NamedPrime(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown field '%s'", name)
+	}
+
+	return NewPrimeField(prime)
+}
+
+// MODP2048Group returns the Schnorr group of quadratic residues modulo the
+// safe prime `modp2048`, of prime order `q = (p - 1) / 2`, generated by 2.
+func MODP2048Group() SchnorrGroup {
+	p, _ := NamedPrime("modp2048")
+	q := new(big.Int).Rsh(p, 1)
+
+	return SchnorrGroup{P: p, Q: q, G: big.NewInt(2)}
+}
+
+// RandomPrime generates a random prime of the given bit length, which is
+// larger than `secret`.
+//
+// It is required that:
+// - 2 <= bits
+// - 0 <= secret < 2^(bits-1)
+//
+// An error is returned if any of the requirements are violated.
+func RandomPrime(bits int, secret *big.Int) (*big.Int, error) {
+	if bits < 2 {
+		return nil, fmt.Errorf("Prime must have at least 2 bits; got %d", bits)
+	}
+
+	// Any prime of `bits` bits is at least 2^(bits-1), and hence larger
+	// than the secret.
+	if secret.Sign() < 0 || secret.BitLen() >= bits {
+		return nil, fmt.Errorf("Secret must be a non-negative value of less than %d bits", bits)
+	}
+
+	return rand.Prime(rand.Reader, bits)
+}
+
+// SafePrime generates a random safe prime `p = 2q + 1` of the given bit
+// length, where q is prime as well.
+//
+// Safe primes are rare, so generating large ones takes considerable time.
+//
+// Returns an error if bits < 3.
+func SafePrime(bits int) (*big.Int, error) {
+	if bits < 3 {
+		return nil, fmt.Errorf("Safe prime must have at least 3 bits; got %d", bits)
+	}
+
+	var p = &big.Int{}
+	for {
+		// rand.Prime sets the top bit of q, so p has exactly `bits` bits
+		q, err := rand.Prime(rand.Reader, bits-1)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Lsh(q, 1)
+		p.Add(p, big.NewInt(1))
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// IsSafePrime checks whether `p` is a safe prime, ie both p and (p - 1) / 2
+// are prime.
+func IsSafePrime(p *big.Int) bool {
+	if p.Cmp(big.NewInt(5)) < 0 || !p.ProbablyPrime(20) {
+		return false
+	}
+
+	q := new(big.Int).Rsh(p, 1)
+	return q.ProbablyPrime(20)
+}
+
+// GenerateSchnorrGroup generates random Schnorr group parameters: a prime
+// modulus `p = kq + 1` of `pBits` bits, a prime group order `q` of `qBits`
+// bits, and a generator of the subgroup of order q.
+//
+// The group is suitable for Feldman and Pedersen VSS over GF(q).
+//
+// It is required that:
+// - 2 <= qBits < pBits
+//
+// An error is returned if any of the requirements are violated.
+func GenerateSchnorrGroup(pBits int, qBits int) (SchnorrGroup, error) {
+	var group SchnorrGroup
+
+	if qBits < 2 || pBits <= qBits {
+		return group, fmt.Errorf("Invalid bit lengths %d and %d for p and q", pBits, qBits)
+	}
+
+	// Pick a random p-candidate X of `pBits` bits, and round it to a
+	// multiple `kq + 1` with even k, such that p is odd. As there might be
+	// few or no primes of this form, q is re-drawn after a number of
+	// candidates proportional to the expected number of tries.
+	min := new(big.Int).Lsh(big.NewInt(1), uint(pBits-1))
+	var p, q = &big.Int{}, &big.Int{}
+	for found := false; !found; {
+		var err error
+		q, err = rand.Prime(rand.Reader, qBits)
+		if err != nil {
+			return group, err
+		}
+
+		twoQ := new(big.Int).Lsh(q, 1)
+		for i := 0; i < 4*pBits; i++ {
+			x, err := rand.Int(rand.Reader, min)
+			if err != nil {
+				return group, err
+			}
+			x.Add(x, min)
+
+			p.Sub(x, new(big.Int).Mod(x, twoQ))
+			p.Add(p, big.NewInt(1))
+			if p.BitLen() == pBits && p.ProbablyPrime(20) {
+				found = true
+				break
+			}
+		}
+	}
+
+	// h^k has order q unless it is 1
+	k := new(big.Int).Sub(p, big.NewInt(1))
+	k.Div(k, q)
+	g := &big.Int{}
+	for h := big.NewInt(2); ; h.Add(h, big.NewInt(1)) {
+		g.Exp(h, k, p)
+		if g.Cmp(big.NewInt(1)) != 0 {
+			break
+		}
+	}
+
+	return NewSchnorrGroup(p, q, g)
+}
+
+// ValidateField checks whether a field is suitable for sharing a secret among
+// `n` parties.
+//
+// Each party is assigned a unique, non-zero element of the field as its ID, so
+// the field must have more than `n` elements.
+//
+// Returns an error if n < 1, the field is too small, or the order of a prime
+// field is not prime.
+func ValidateField(field Field, n int) error {
+	if n < 1 {
+		return fmt.Errorf("Invalid number of parties %d", n)
+	}
+
+	if gf, ok := field.(GF); ok && !gf.P.ProbablyPrime(20) {
+		return fmt.Errorf("Field must be of prime order; %d is not", gf.P)
+	}
+
+	if field.Order().Cmp(big.NewInt(int64(n))) <= 0 {
+		return fmt.Errorf("%w: order %d for %d parties", ErrFieldTooSmall, field.Order(), n)
+	}
+
+	return nil
+}
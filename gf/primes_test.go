@@ -0,0 +1,206 @@
+package gf
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+)
+
+func TestNamedPrime(t *testing.T) {
+	names := PrimeNames()
+	if !sort.StringsAreSorted(names) || len(names) != len(namedPrimes) {
+		t.Errorf("Expected sorted list of all %d prime names; got %v", len(namedPrimes), names)
+	}
+
+	for _, name := range names {
+		p, err := NamedPrime(name)
+		if err != nil {
+			t.Fatalf("Error getting prime '%s': %v", name, err)
+		}
+
+		if !p.ProbablyPrime(20) {
+			t.Errorf("Expected prime '%s' to be prime; %d is not", name, p)
+		}
+	}
+
+	p, _ := NamedPrime("p255")
+	if p.BitLen() != 255 {
+		t.Errorf("Expected p255 to have 255 bits; got %d", p.BitLen())
+	}
+
+	p, _ = NamedPrime("modp2048")
+	if p.BitLen() != 2048 || !IsSafePrime(p) {
+		t.Errorf("Expected modp2048 to be a 2048-bit safe prime")
+	}
+
+	_, err := NamedPrime("p42")
+	if err == nil {
+		t.Errorf("Expected error for unknown prime; got none")
+	}
+}
+
+func TestNamedField(t *testing.T) {
+	for _, name := range FieldNames() {
+		_, err := NamedField(name)
+		if err != nil {
+			t.Errorf("Error getting field '%s': %v", name, err)
+		}
+	}
+
+	field, _ := NamedField("gf256")
+	if _, ok := field.(GF256); !ok {
+		t.Errorf("Expected GF256 for gf256; got %T", field)
+	}
+
+	field, _ = NamedField("p127")
+	if _, ok := field.(Mersenne127); !ok {
+		t.Errorf("Expected Mersenne127 for p127; got %T", field)
+	}
+
+	_, err := NamedField("1019")
+	if err == nil {
+		t.Errorf("Expected error for unknown field; got none")
+	}
+}
+
+func TestMODP2048Group(t *testing.T) {
+	group := MODP2048Group()
+
+	_, err := NewSchnorrGroup(group.P, group.Q, group.G)
+	if err != nil {
+		t.Errorf("Expected valid Schnorr group; got '%s'", err)
+	}
+}
+
+func TestRandomPrime(t *testing.T) {
+	secret := big.NewInt(1000)
+
+	p, err := RandomPrime(64, secret)
+	if err != nil {
+		t.Fatalf("Error generating random prime: %v", err)
+	}
+	if p.BitLen() != 64 || !p.ProbablyPrime(20) || p.Cmp(secret) <= 0 {
+		t.Errorf("Expected 64-bit prime larger than %d; got %d", secret, p)
+	}
+
+	_, err = RandomPrime(10, secret)
+	if err == nil {
+		t.Errorf("Expected error if secret does not fit; got none")
+	}
+
+	_, err = RandomPrime(1, big.NewInt(0))
+	if err == nil {
+		t.Errorf("Expected error if bit length is too small; got none")
+	}
+
+	_, err = RandomPrime(64, big.NewInt(-1))
+	if err == nil {
+		t.Errorf("Expected error if secret is negative; got none")
+	}
+}
+
+func TestSafePrime(t *testing.T) {
+	p, err := SafePrime(64)
+	if err != nil {
+		t.Fatalf("Error generating safe prime: %v", err)
+	}
+	if p.BitLen() != 64 || !IsSafePrime(p) {
+		t.Errorf("Expected 64-bit safe prime; got %d", p)
+	}
+
+	_, err = SafePrime(2)
+	if err == nil {
+		t.Errorf("Expected error if bit length is too small; got none")
+	}
+
+	checks := []struct {
+		p    int64
+		safe bool
+	}{
+		{5, true},
+		{7, true},
+		{11, true},
+		{13, false},
+		{23, true},
+		{2039, true},
+		{2041, false},
+	}
+
+	for _, check := range checks {
+		if IsSafePrime(big.NewInt(check.p)) != check.safe {
+			t.Errorf("Expected IsSafePrime(%d) = %t", check.p, check.safe)
+		}
+	}
+}
+
+func TestGenerateSchnorrGroup(t *testing.T) {
+	group, err := GenerateSchnorrGroup(128, 64)
+	if err != nil {
+		t.Fatalf("Error generating Schnorr group: %v", err)
+	}
+
+	if group.P.BitLen() != 128 || group.Q.BitLen() != 64 {
+		t.Errorf("Expected p and q of 128 and 64 bits; got %d and %d", group.P.BitLen(), group.Q.BitLen())
+	}
+
+	_, err = NewSchnorrGroup(group.P, group.Q, group.G)
+	if err != nil {
+		t.Errorf("Expected valid Schnorr group; got '%s'", err)
+	}
+
+	_, err = GenerateSchnorrGroup(64, 64)
+	if err == nil {
+		t.Errorf("Expected error if q is not smaller than p; got none")
+	}
+}
+
+func TestGenerateSchnorrGroupSafePrime(t *testing.T) {
+	// The only candidate for p is 2q + 1, so q must be re-drawn until it is
+	// a Sophie Germain prime.
+	for _, qBits := range []int{8, 16, 32} {
+		group, err := GenerateSchnorrGroup(qBits+1, qBits)
+		if err != nil {
+			t.Fatalf("Error generating Schnorr group: %v", err)
+		}
+
+		if group.P.BitLen() != qBits+1 || group.Q.BitLen() != qBits {
+			t.Errorf("Expected p and q of %d and %d bits; got %d and %d", qBits+1, qBits, group.P.BitLen(), group.Q.BitLen())
+		}
+
+		if !IsSafePrime(group.P) {
+			t.Errorf("Expected %d to be a safe prime; was not", group.P)
+		}
+
+		_, err = NewSchnorrGroup(group.P, group.Q, group.G)
+		if err != nil {
+			t.Errorf("Expected valid Schnorr group; got '%s'", err)
+		}
+	}
+}
+
+func TestValidateField(t *testing.T) {
+	if err := ValidateField(GF{P: big.NewInt(53)}, 52); err != nil {
+		t.Errorf("Expected GF(53) to be suitable for 52 parties; got '%s'", err)
+	}
+
+	if err := ValidateField(GF{P: big.NewInt(53)}, 53); !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected GF(53) to be too small for 53 parties; got %v", err)
+	}
+
+	if err := ValidateField(GF256{}, 255); err != nil {
+		t.Errorf("Expected GF(2^8) to be suitable for 255 parties; got '%s'", err)
+	}
+
+	if err := ValidateField(GF256{}, 256); err == nil {
+		t.Errorf("Expected GF(2^8) to be too small for 256 parties")
+	}
+
+	if err := ValidateField(GF{P: big.NewInt(51)}, 5); err == nil {
+		t.Errorf("Expected error for field of non-prime order")
+	}
+
+	if err := ValidateField(GF{P: big.NewInt(53)}, 0); err == nil {
+		t.Errorf("Expected error for zero parties")
+	}
+}
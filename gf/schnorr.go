@@ -0,0 +1,144 @@
+package gf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// SchnorrGroup implements the subgroup of prime order q of the multiplicative
+// group of integers modulo a prime p, where p = kq + 1.
+//
+// Exponents of group elements are elements of GF(q), which makes the group
+// suitable for committing to coefficients of polynomials over GF(q).
+type SchnorrGroup struct {
+	// Modulus of the group
+	P *big.Int
+	// Prime order of the group
+	Q *big.Int
+	// Generator of the group
+	G *big.Int
+}
+
+// NewSchnorrGroup creates a new Schnorr group.
+//
+// Returns an error if p or q are not prime, q does not divide p - 1, or g
+// does not generate the subgroup of order q.
+func NewSchnorrGroup(p *big.Int, q *big.Int, g *big.Int) (SchnorrGroup, error) {
+	group := SchnorrGroup{P: p, Q: q, G: g}
+
+	// False positive probability of at most (1/4)^n
+	if !p.ProbablyPrime(20) {
+		return group, fmt.Errorf("Modulus must be prime; %d is not", p)
+	}
+
+	if !q.ProbablyPrime(20) {
+		return group, fmt.Errorf("Group order must be prime; %d is not", q)
+	}
+
+	// A group of order 2 has no element other than 1 and g, so no second
+	// generator can be derived from it
+	if q.Cmp(big.NewInt(3)) < 0 {
+		return group, fmt.Errorf("Group order must be at least 3; got %d", q)
+	}
+
+	var rem = &big.Int{}
+	rem.Sub(p, big.NewInt(1))
+	rem.Mod(rem, q)
+	if rem.Sign() != 0 {
+		return group, fmt.Errorf("Group order %d does not divide %d - 1", q, p)
+	}
+
+	// As q is prime, any element other than 1 of the subgroup generates it
+	if !group.IsElement(g) || g.Cmp(big.NewInt(1)) == 0 {
+		return group, fmt.Errorf("%d does not generate a subgroup of order %d", g, q)
+	}
+
+	return group, nil
+}
+
+// ScalarField returns the field GF(q) of exponents of the group.
+func (group SchnorrGroup) ScalarField() GF {
+	return GF{P: group.Q}
+}
+
+// Mul performs the group operation, ie multiplication modulo p.
+func (group SchnorrGroup) Mul(a *big.Int, b *big.Int) *big.Int {
+	var prod = &big.Int{}
+	prod.Mul(a, b)          // a * b
+	prod.Mod(prod, group.P) // a * b mod p
+
+	return prod
+}
+
+// Exp performs modular exponentiation in the group.
+func (group SchnorrGroup) Exp(b *big.Int, e *big.Int) *big.Int {
+	var pow = &big.Int{}
+	pow.Exp(b, e, group.P) // b^e mod p
+
+	return pow
+}
+
+// Commit returns the commitment g^x to an exponent x.
+func (group SchnorrGroup) Commit(x *big.Int) *big.Int {
+	return group.Exp(group.G, x)
+}
+
+// IsElement checks if a value is an element of the subgroup of order q.
+func (group SchnorrGroup) IsElement(x *big.Int) bool {
+	// 0 < x < p
+	if x.Sign() != 1 || x.Cmp(group.P) != -1 {
+		return false
+	}
+
+	// x^q = 1 mod p
+	return group.Exp(x, group.Q).Cmp(big.NewInt(1)) == 0
+}
+
+// DeriveGenerator deterministically derives a generator of the group from a
+// seed, such that nobody knows its discrete logarithm with respect to `G`.
+//
+// The seed and a counter are hashed to an integer modulo p, which is then
+// raised to the power of the cofactor (p - 1) / q to map it into the subgroup.
+// The counter is incremented until the result is not the identity, and not
+// `G` itself.
+//
+// Returns nil if q is less than 3 or does not divide p - 1, as the group then
+// has no such generator.
+func (group SchnorrGroup) DeriveGenerator(seed []byte) *big.Int {
+	if group.Q.Cmp(big.NewInt(3)) < 0 {
+		return nil
+	}
+
+	var cofactor, rem = &big.Int{}, &big.Int{}
+	cofactor.Sub(group.P, big.NewInt(1))
+	cofactor.DivMod(cofactor, group.Q, rem) // (p - 1) / q
+	if rem.Sign() != 0 {
+		return nil
+	}
+
+	// Hashing 64 bits more than the size of p makes the bias of the
+	// reduction modulo p negligible.
+	blocks := (group.P.BitLen() + 64 + 255) / 256
+
+	for counter := uint32(0); ; counter++ {
+		var digest []byte
+		for block := uint32(0); block < uint32(blocks); block++ {
+			h := sha256.New()
+			h.Write(seed)
+			binary.Write(h, binary.BigEndian, counter)
+			binary.Write(h, binary.BigEndian, block)
+			digest = h.Sum(digest)
+		}
+
+		var x = &big.Int{}
+		x.SetBytes(digest)
+		x.Mod(x, group.P)
+		x = group.Exp(x, cofactor)
+
+		if x.Cmp(big.NewInt(1)) != 0 && x.Cmp(group.G) != 0 && x.Sign() != 0 {
+			return x
+		}
+	}
+}
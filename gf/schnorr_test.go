@@ -0,0 +1,117 @@
+package gf
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewSchnorrGroup(t *testing.T) {
+	group, err := NewSchnorrGroup(big.NewInt(23), big.NewInt(11), big.NewInt(4))
+	if err != nil {
+		t.Errorf("Error while creating Schnorr group: %v", err)
+	}
+	if group.ScalarField().P.Cmp(big.NewInt(11)) != 0 {
+		t.Errorf("Expected scalar field of order 11; got %d", group.ScalarField().P)
+	}
+
+	invalid := []struct {
+		p int64
+		q int64
+		g int64
+	}{
+		// p not prime
+		{21, 11, 4},
+		// q not prime
+		{23, 22, 4},
+		// q does not divide p - 1
+		{23, 7, 4},
+		// q too small
+		{5, 2, 4},
+		// g of order 22
+		{23, 11, 5},
+		// g of order 1
+		{23, 11, 1},
+		// g not in group
+		{23, 11, 23},
+	}
+
+	for _, check := range invalid {
+		_, err = NewSchnorrGroup(big.NewInt(check.p), big.NewInt(check.q), big.NewInt(check.g))
+		if err == nil {
+			t.Errorf("Expected error for p = %d, q = %d, g = %d; got none", check.p, check.q, check.g)
+		}
+	}
+}
+
+func TestSchnorrGroupArithmetic(t *testing.T) {
+	group := SchnorrGroup{P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4)}
+
+	checks := []struct {
+		a    int64
+		b    int64
+		prod int64
+		pow  int64
+	}{
+		{4, 2, 8, 16},
+		{13, 18, 4, 9},
+		{6, 11, 20, 1},
+	}
+
+	for _, check := range checks {
+		actual := group.Mul(big.NewInt(check.a), big.NewInt(check.b))
+		if actual.Cmp(big.NewInt(check.prod)) != 0 {
+			t.Errorf("%d * %d mod %d = %d; got %d", check.a, check.b, group.P, check.prod, actual)
+		}
+
+		actual = group.Exp(big.NewInt(check.a), big.NewInt(check.b))
+		if actual.Cmp(big.NewInt(check.pow)) != 0 {
+			t.Errorf("%d ^ %d mod %d = %d; got %d", check.a, check.b, group.P, check.pow, actual)
+		}
+	}
+
+	actual := group.Commit(big.NewInt(3))
+	if actual.Cmp(big.NewInt(18)) != 0 {
+		t.Errorf("Expected g^3 = 18; got %d", actual)
+	}
+}
+
+func TestSchnorrGroupIsElement(t *testing.T) {
+	group := SchnorrGroup{P: big.NewInt(23), Q: big.NewInt(11), G: big.NewInt(4)}
+
+	valid := []int64{1, 2, 4, 8, 16}
+	for _, x := range valid {
+		if !group.IsElement(big.NewInt(x)) {
+			t.Errorf("Expected %d to be group element; was not", x)
+		}
+	}
+
+	invalid := []int64{-4, 0, 5, 22, 23, 27}
+	for _, x := range invalid {
+		if group.IsElement(big.NewInt(x)) {
+			t.Errorf("Expected %d to not be group element; but was", x)
+		}
+	}
+}
+
+func TestDeriveGenerator(t *testing.T) {
+	group := SchnorrGroup{P: big.NewInt(2039), Q: big.NewInt(1019), G: big.NewInt(4)}
+
+	h := group.DeriveGenerator([]byte("pedersen"))
+	if !group.IsElement(h) {
+		t.Errorf("Expected derived generator %d to be group element; was not", h)
+	}
+	if h.Cmp(big.NewInt(1)) == 0 || h.Cmp(group.G) == 0 {
+		t.Errorf("Expected derived generator to differ from 1 and g; got %d", h)
+	}
+
+	again := group.DeriveGenerator([]byte("pedersen"))
+	if h.Cmp(again) != 0 {
+		t.Errorf("Expected derivation to be deterministic; got %d and %d", h, again)
+	}
+
+	// The only elements of a group of order 2 are 1 and g
+	small := SchnorrGroup{P: big.NewInt(5), Q: big.NewInt(2), G: big.NewInt(4)}
+	if h := small.DeriveGenerator([]byte("pedersen")); h != nil {
+		t.Errorf("Expected no generator for group of order 2; got %d", h)
+	}
+}
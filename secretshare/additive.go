@@ -0,0 +1,248 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// AdditiveShare represents a single party's share of a secret shared
+// additively among n parties, such that the shares sum up to the secret.
+//
+// The threshold of an additive share is the number of parties n, as all of
+// them are required to recover the secret.
+type AdditiveShare struct {
+	Share
+}
+
+// AdditiveSplit implements n-out-of-n secret sharing, where the shares are
+// random elements of the field which sum up to the secret.
+//
+// This is cheaper than `TOutOfN` with `t = n`, as neither sharing nor
+// recovery evaluate polynomials or Lagrange coefficients. Any n - 1 shares
+// are uniformly random, and hence reveal nothing about the secret.
+//
+// It is required that:
+// - 1 < n < |field|, such that each share has a unique, non-zero ID
+// - secret is an element of the field
+//
+// Returns a slice containing the shares, with IDs 1 to n.
+// An error is returned if any of the requirements are violated, wrapping
+// `ErrThreshold`, `ErrFieldTooSmall` or `ErrNotInField` respectively.
+func AdditiveSplit(secret *big.Int, n int, field gf.Field) ([]AdditiveShare, error) {
+	var shares []AdditiveShare
+
+	if n <= 1 {
+		return shares, fmt.Errorf("%w: n = %d", ErrThreshold, n)
+	}
+
+	if field.Order().Cmp(big.NewInt(int64(n))) <= 0 {
+		return shares, fmt.Errorf("%w: order %d for %d shares", ErrFieldTooSmall, field.Order(), n)
+	}
+
+	if secret == nil || !field.IsGroupElement(secret) {
+		return shares, fmt.Errorf("%w: secret", ErrNotInField)
+	}
+
+	secretID, err := newSecretID()
+	if err != nil {
+		return shares, err
+	}
+
+	// All shares but the last are random, the last one ensures they sum up
+	// to the secret.
+	shares = make([]AdditiveShare, n)
+	rest := new(big.Int).Set(secret)
+	for i := range shares {
+		value := rest
+		if i < n-1 {
+			if value, err = field.Rand(); err != nil {
+				return shares, err
+			}
+			rest = field.Sub(rest, value)
+		}
+
+		shares[i] = AdditiveShare{Share{
+			ID:        i + 1,
+			Value:     value,
+			Threshold: n,
+			SecretID:  secretID,
+			Order:     field.Order(),
+		}}
+	}
+
+	return shares, nil
+}
+
+// AdditiveRecover recovers a secret from all shares created by
+// `AdditiveSplit`, by summing them up.
+//
+// Returns an error if no shares are supplied, if their number does not match
+// their threshold, if they are not unique, or if their metadata shows that
+// they do not belong to the same secret.
+func AdditiveRecover(shares []AdditiveShare, field gf.Field) (*big.Int, error) {
+	var sum = &big.Int{}
+
+	if len(shares) == 0 {
+		return sum, fmt.Errorf("No shares supplied")
+	}
+
+	plain := additivePlain(shares)
+	for _, share := range plain {
+		if share.Threshold != 0 && share.Threshold != len(shares) {
+			return sum, fmt.Errorf("%w: all %d shares required; got %d", ErrThreshold, share.Threshold, len(shares))
+		}
+	}
+
+	if err := checkSameSecret(plain, field); err != nil {
+		return sum, err
+	}
+
+	if err := checkIDs(shareIDs(plain), field); err != nil {
+		return sum, err
+	}
+
+	for _, share := range plain {
+		if share.Value == nil || !field.IsGroupElement(share.Value) {
+			return sum, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+		}
+
+		sum = field.Add(sum, share.Value)
+	}
+
+	return sum, nil
+}
+
+// ShamirToAdditive converts a Shamir share into an additive share among the
+// parties with IDs `ids`, without any interaction.
+//
+// Recall that the secret is `s = Sum for j [ l_j(0) * s_j ]`, where `l_j` are
+// the Lagrange base polynomials of the parties' IDs. Hence the terms
+// `l_j(0) * s_j` are additive shares of the secret.
+//
+// It is required that:
+// - ids contain the share's ID and at least `t` unique, non-zero IDs
+// - all parties convert their shares using the same IDs
+//
+// Returns the additive share, whose threshold is the number of parties.
+// An error is returned if any of the requirements are violated.
+func ShamirToAdditive(share Share, ids []int, field gf.Field) (AdditiveShare, error) {
+	var out AdditiveShare
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return out, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+	}
+
+	if share.Threshold != 0 && len(ids) < share.Threshold {
+		return out, fmt.Errorf("%w: at least %d parties required; got %d", ErrThreshold, share.Threshold, len(ids))
+	}
+
+	if err := checkIDs(ids, field); err != nil {
+		return out, err
+	}
+
+	j := -1
+	xs := make([]*big.Int, len(ids))
+	for i, id := range ids {
+		if id == share.ID {
+			j = i
+		}
+		xs[i] = big.NewInt(int64(id))
+	}
+
+	if j == -1 {
+		return out, fmt.Errorf("Share with ID %d is not among the parties", share.ID)
+	}
+
+	basePoly := gf.BasePolynomial(j, xs, field) // l_j(0)
+
+	out.Share = Share{
+		ID:        share.ID,
+		Value:     field.Mul(share.Value, basePoly), // l_j(0) * s_j
+		Threshold: len(ids),
+		SecretID:  share.SecretID,
+		Order:     field.Order(),
+	}
+
+	return out, nil
+}
+
+// AdditiveToShamir creates an additive shareholder's contribution to
+// converting the additive shares into t-out-of-n Shamir shares among the
+// parties with IDs `newIDs`.
+//
+// The shareholder shares its own value with a random polynomial of degree
+// `t - 1`, and returns the sub-share for each new shareholder. All additive
+// shareholders must contribute, and each new shareholder sums up the
+// sub-shares it received with `CombineAdditiveReshares`.
+//
+// It is required that:
+// - 1 < t <= len(newIDs)
+// - newIDs are unique, non-zero elements of the field
+//
+// Returns a slice containing one sub-share for each new ID.
+// An error is returned if any of the requirements are violated.
+func AdditiveToShamir(share AdditiveShare, t int, newIDs []int, field gf.Field) ([]SubShare, error) {
+	subshares := make([]SubShare, len(newIDs))
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return subshares, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+	}
+
+	shares, _, err := dealShares(share.Value, t, newIDs, field)
+	if err != nil {
+		return subshares, err
+	}
+
+	for i, sub := range shares {
+		sub.SecretID = share.SecretID
+		sub.Order = field.Order()
+
+		subshares[i] = SubShare{From: share.ID, OldThreshold: share.Threshold, Share: sub}
+	}
+
+	return subshares, nil
+}
+
+// CombineAdditiveReshares combines the sub-shares a new shareholder received
+// from all additive shareholders into its Shamir share.
+//
+// As the secret is the sum of the additive shares, the new share is the sum of
+// the sub-shares: `s'_k = Sum for j [ g_j(k) ]`
+//
+// Returns an error if the sub-shares are destined for different shareholders,
+// do not stem from unique additive shareholders, or their number does not
+// match the number of additive shareholders.
+func CombineAdditiveReshares(subshares []SubShare, field gf.Field) (Share, error) {
+	var share Share
+
+	if err := checkSubShares(subshares, field); err != nil {
+		return share, err
+	}
+
+	var sum = &big.Int{}
+	for _, sub := range subshares {
+		sum = field.Add(sum, sub.Value)
+	}
+
+	first := subshares[0]
+	share = Share{
+		ID:        first.ID,
+		Value:     sum,
+		Threshold: first.Threshold,
+		SecretID:  first.SecretID,
+		Order:     field.Order(),
+	}
+
+	return share, nil
+}
+
+// additivePlain returns the plain shares of additive shares.
+func additivePlain(shares []AdditiveShare) []Share {
+	plain := make([]Share, len(shares))
+	for i, share := range shares {
+		plain[i] = share.Share
+	}
+
+	return plain
+}
@@ -0,0 +1,192 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func TestAdditiveSplit(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+	secret := big.NewInt(42)
+
+	shares, err := AdditiveSplit(secret, 4, field)
+	if err != nil {
+		t.Fatalf("Error creating additive shares: %v", err)
+	}
+
+	if len(shares) != 4 {
+		t.Fatalf("Expected 4 shares; got %d", len(shares))
+	}
+
+	var sum = &big.Int{}
+	for i, share := range shares {
+		if share.ID != i+1 || share.Threshold != 4 {
+			t.Errorf("Expected share with ID %d and threshold 4; got ID %d and threshold %d", i+1, share.ID, share.Threshold)
+		}
+		sum = field.Add(sum, share.Value)
+	}
+	if sum.Cmp(secret) != 0 {
+		t.Errorf("Expected shares to sum up to %d; got %d", secret, sum)
+	}
+
+	recovered, err := AdditiveRecover(shares, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if recovered.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, recovered)
+	}
+}
+
+func TestAdditiveSplitInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	_, err := AdditiveSplit(big.NewInt(42), 1, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n <= 1; got %v", err)
+	}
+
+	_, err = AdditiveSplit(big.NewInt(42), -1, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n < 0; got %v", err)
+	}
+
+	_, err = AdditiveSplit(big.NewInt(42), 53, field)
+	if !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected ErrFieldTooSmall if n >= p; got %v", err)
+	}
+
+	_, err = AdditiveSplit(big.NewInt(53), 3, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if secret not in field; got %v", err)
+	}
+}
+
+func TestAdditiveRecoverInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	shares, err := AdditiveSplit(big.NewInt(42), 3, field)
+	if err != nil {
+		t.Fatalf("Error creating additive shares: %v", err)
+	}
+
+	_, err = AdditiveRecover([]AdditiveShare{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no shares given; got none")
+	}
+
+	_, err = AdditiveRecover(shares[:2], field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if not all shares given; got %v", err)
+	}
+
+	_, err = AdditiveRecover([]AdditiveShare{shares[0], shares[1], shares[1]}, field)
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if duplicate shares given; got %v", err)
+	}
+
+	other, err := AdditiveSplit(big.NewInt(42), 3, field)
+	if err != nil {
+		t.Fatalf("Error creating additive shares: %v", err)
+	}
+	_, err = AdditiveRecover([]AdditiveShare{shares[0], shares[1], other[2]}, field)
+	if err == nil {
+		t.Errorf("Expected error if shares belong to different secrets; got none")
+	}
+}
+
+func TestShamirToAdditive(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(789)
+
+	shamir, _, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n shares: %v", err)
+	}
+
+	// Any set of at least t parties may convert their shares
+	for _, set := range [][]int{{1, 2, 3}, {5, 2, 4}, {1, 2, 3, 4, 5}} {
+		additive := make([]AdditiveShare, len(set))
+		for i, id := range set {
+			additive[i], err = ShamirToAdditive(shamir[id-1], set, field)
+			if err != nil {
+				t.Fatalf("Error converting share %d: %v", id, err)
+			}
+		}
+
+		recovered, err := AdditiveRecover(additive, field)
+		if err != nil {
+			t.Fatalf("Error recovering secret: %v", err)
+		}
+		if recovered.Cmp(secret) != 0 {
+			t.Errorf("Expected to recover %d from parties %v; got %d", secret, set, recovered)
+		}
+	}
+
+	_, err = ShamirToAdditive(shamir[0], []int{1, 2}, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if fewer than t parties; got %v", err)
+	}
+
+	_, err = ShamirToAdditive(shamir[0], []int{2, 3, 4}, field)
+	if err == nil {
+		t.Errorf("Expected error if share is not among the parties; got none")
+	}
+
+	_, err = ShamirToAdditive(shamir[0], []int{1, 2, 2}, field)
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if parties are not unique; got %v", err)
+	}
+}
+
+func TestAdditiveToShamir(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(321)
+	newIDs := []int{1, 2, 3, 4, 5}
+
+	additive, err := AdditiveSplit(secret, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating additive shares: %v", err)
+	}
+
+	// received[k] holds the sub-shares for the k-th new shareholder
+	received := make([][]SubShare, len(newIDs))
+	for _, share := range additive {
+		subshares, err := AdditiveToShamir(share, 2, newIDs, field)
+		if err != nil {
+			t.Fatalf("Error converting additive share %d: %v", share.ID, err)
+		}
+
+		for k, sub := range subshares {
+			received[k] = append(received[k], sub)
+		}
+	}
+
+	shamir := make([]Share, len(newIDs))
+	for k := range newIDs {
+		shamir[k], err = CombineAdditiveReshares(received[k], field)
+		if err != nil {
+			t.Fatalf("Error combining sub-shares: %v", err)
+		}
+	}
+
+	recovered, err := TOutOfNRecover([]Share{shamir[4], shamir[1]}, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if recovered.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, recovered)
+	}
+
+	_, err = CombineAdditiveReshares(received[0][:2], field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if sub-shares are missing; got %v", err)
+	}
+
+	_, err = CombineAdditiveReshares([]SubShare{received[0][0], received[1][1], received[0][2]}, field)
+	if err == nil {
+		t.Errorf("Expected error if sub-shares are for different shareholders; got none")
+	}
+}
@@ -0,0 +1,180 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// ByteShare represents a single party's share of a byte-oriented secret.
+//
+// The secret is split into chunks, each of which is shared using its own
+// polynomial. `Values` contains the party's share of each chunk, in order.
+type ByteShare struct {
+	ID int
+	// Length of the original secret in bytes
+	Length int
+	Values []*big.Int
+	// Number of shares required to recover the secret, or 0 if unknown
+	Threshold int
+	// Random identifier shared by all shares of the same secret, or nil if
+	// unknown
+	SecretID []byte
+	// Order of the field the secret was shared in, or nil if unknown
+	Order *big.Int
+}
+
+// ChunkSize returns the number of bytes of a secret which are packed into a
+// single element of the field `field`.
+//
+// The chunk size is chosen such that any chunk, interpreted as a big-endian
+// integer, is guaranteed to be smaller than the order of the field.
+func ChunkSize(field gf.Field) int {
+	return (field.Order().BitLen() - 1) / 8
+}
+
+// SplitBytes implements t-out-of-n secret sharing of an arbitrary byte
+// string, using polynomials over a finite field.
+//
+// The secret is split into chunks of `ChunkSize(field)` bytes, each of which
+// is shared with its own random polynomial using `TOutOfN`.
+//
+// It is required that:
+// - 1 < t <= n
+// - t, n are elements of the field
+// - the secret is not empty
+// - the field has at least 256 elements, so a byte fits into an element
+//
+// Using `gf.GF256` results in one field element per byte of the secret, the
+// same layout as created by `TOutOfNGF256`.
+//
+// Returns a slice containing the shares.
+// An error is returned if any of the requirements are violated.
+func SplitBytes(secret []byte, t int, n int, field gf.Field) ([]ByteShare, error) {
+	var shares []ByteShare
+
+	if t <= 1 || t > n {
+		return shares, fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, n)
+	}
+
+	chunkSize := ChunkSize(field)
+	if chunkSize < 1 {
+		return shares, fmt.Errorf("%w: order %d cannot hold a single byte", ErrFieldTooSmall, field.Order())
+	}
+
+	if len(secret) == 0 {
+		return shares, fmt.Errorf("Secret must not be empty")
+	}
+
+	secretID, err := newSecretID()
+	if err != nil {
+		return shares, err
+	}
+
+	chunks := (len(secret) + chunkSize - 1) / chunkSize
+	shares = make([]ByteShare, n)
+	for i := 0; i < n; i++ {
+		shares[i] = ByteShare{
+			ID:        i + 1,
+			Length:    len(secret),
+			Values:    make([]*big.Int, chunks),
+			Threshold: t,
+			SecretID:  secretID,
+			Order:     field.Order(),
+		}
+	}
+
+	for c := 0; c < chunks; c++ {
+		start := c * chunkSize
+		end := start + chunkSize
+		if end > len(secret) {
+			end = len(secret)
+		}
+
+		var chunk = &big.Int{}
+		chunk.SetBytes(secret[start:end])
+
+		chunkShares, _, err := TOutOfN(chunk, t, n, field)
+		if err != nil {
+			return shares, err
+		}
+
+		for i, share := range chunkShares {
+			shares[i].Values[c] = share.Value
+		}
+	}
+
+	return shares, nil
+}
+
+// CombineBytes recovers a byte-oriented secret from t out of n shares.
+//
+// As with `TOutOfNRecover`, any number of at least `t` unique shares may be
+// supplied, as long as they carry the threshold.
+//
+// Returns an error if shares are not unique, are inconsistent, or if they do
+// not belong to the same secret.
+func CombineBytes(shares []ByteShare, field gf.Field) ([]byte, error) {
+	var secret []byte
+
+	if len(shares) == 0 {
+		return secret, fmt.Errorf("No shares supplied")
+	}
+
+	chunkSize := ChunkSize(field)
+	if chunkSize < 1 {
+		return secret, fmt.Errorf("%w: order %d cannot hold a single byte", ErrFieldTooSmall, field.Order())
+	}
+
+	// The length is bounded by the chunks of the first share before
+	// allocating the secret, as it might stem from an untrusted encoding.
+	length := shares[0].Length
+	if length < 1 || length > len(shares[0].Values)*chunkSize {
+		return secret, fmt.Errorf("Share with ID %d has invalid secret length of %d bytes for %d chunks", shares[0].ID, length, len(shares[0].Values))
+	}
+
+	chunks := (length + chunkSize - 1) / chunkSize
+	for _, share := range shares {
+		if share.Length != length || len(share.Values) != chunks {
+			return secret, fmt.Errorf("Share with ID %d does not match secret length of %d bytes", share.ID, length)
+		}
+	}
+
+	// All chunks are shared among the same IDs, so the Lagrange
+	// coefficients are only calculated once.
+	cache := gf.NewLagrangeCache(field)
+
+	secret = make([]byte, length)
+	chunkShares := make([]Share, len(shares))
+	for c := 0; c < chunks; c++ {
+		for i, share := range shares {
+			chunkShares[i] = Share{
+				ID:        share.ID,
+				Value:     share.Values[c],
+				Threshold: share.Threshold,
+				SecretID:  share.SecretID,
+				Order:     share.Order,
+			}
+		}
+
+		chunk, err := TOutOfNRecoverCached(chunkShares, cache)
+		if err != nil {
+			return secret, err
+		}
+
+		start := c * chunkSize
+		end := start + chunkSize
+		if end > length {
+			end = length
+		}
+
+		// The chunk is left-padded with zeroes to its original width,
+		// which restores any leading zero bytes of the secret.
+		if chunk.BitLen() > (end-start)*8 {
+			return secret, fmt.Errorf("Recovered chunk %d does not fit into %d bytes", c, end-start)
+		}
+		chunk.FillBytes(secret[start:end])
+	}
+
+	return secret, nil
+}
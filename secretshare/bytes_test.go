@@ -0,0 +1,185 @@
+package secretshare
+
+import (
+	"bytes"
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func mersenne127() gf.GF {
+	// 2^127 - 1
+	p := big.NewInt(1)
+	p.Lsh(p, 127)
+	p.Sub(p, big.NewInt(1))
+
+	return gf.GF{P: p}
+}
+
+func TestChunkSize(t *testing.T) {
+	checks := []struct {
+		field gf.Field
+		size  int
+	}{
+		{gf.GF{P: big.NewInt(53)}, 0},
+		{gf.GF{P: big.NewInt(257)}, 1},
+		{gf.GF256{}, 1},
+		{mersenne127(), 15},
+	}
+
+	for _, check := range checks {
+		actual := ChunkSize(check.field)
+		if actual != check.size {
+			t.Errorf("Expected chunk size %d for field of order %d; got %d", check.size, check.field.Order(), actual)
+		}
+	}
+}
+
+func TestSplitBytes(t *testing.T) {
+	secrets := [][]byte{
+		[]byte("correct horse battery staple"),
+		{0x00, 0x00, 0x01, 0x02},
+		{0x00},
+		// 32 bytes, ie. an AES-256 key with leading zeroes
+		{
+			0x00, 0x00, 0xde, 0xad, 0xbe, 0xef, 0x00, 0x00,
+			0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+			0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00,
+		},
+	}
+	fields := []gf.Field{
+		gf.GF{P: big.NewInt(257)},
+		gf.GF256{},
+		mersenne127(),
+	}
+
+	for _, field := range fields {
+		for _, secret := range secrets {
+			shares, err := SplitBytes(secret, 3, 5, field)
+			if err != nil {
+				t.Fatalf("Error splitting secret: %v", err)
+			}
+
+			if len(shares) != 5 {
+				t.Errorf("Expected 5 shares; got %d", len(shares))
+			}
+
+			sharesSubset := []ByteShare{
+				shares[4],
+				shares[1],
+				shares[2],
+			}
+			reconstructed, err := CombineBytes(sharesSubset, field)
+			if err != nil {
+				t.Fatalf("Error combining shares: %v", err)
+			}
+			if !bytes.Equal(secret, reconstructed) {
+				t.Errorf("Reconstructed secret %x does not match %x", reconstructed, secret)
+			}
+
+			reconstructed, err = CombineBytes(shares, field)
+			if err != nil {
+				t.Fatalf("Error combining all shares: %v", err)
+			}
+			if !bytes.Equal(secret, reconstructed) {
+				t.Errorf("Reconstructed secret %x does not match %x", reconstructed, secret)
+			}
+		}
+	}
+}
+
+func TestSplitBytesInvalidInputs(t *testing.T) {
+	_, err := SplitBytes([]byte("secret"), 3, 5, gf.GF{P: big.NewInt(53)})
+	if err == nil {
+		t.Errorf("Expected error if field cannot hold a byte; got none")
+	}
+
+	_, err = SplitBytes([]byte{}, 3, 5, mersenne127())
+	if err == nil {
+		t.Errorf("Expected error if secret is empty; got none")
+	}
+
+	_, err = SplitBytes([]byte("secret"), 6, 5, mersenne127())
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t > n; got %v", err)
+	}
+
+	_, err = SplitBytes([]byte("secret"), 1, -1, mersenne127())
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n < 0; got %v", err)
+	}
+}
+
+func TestCombineBytesInvalidInputs(t *testing.T) {
+	field := mersenne127()
+
+	_, err := CombineBytes([]ByteShare{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no shares given; got none")
+	}
+
+	shares, err := SplitBytes([]byte("secret"), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+	other, err := SplitBytes([]byte("a much longer secret value"), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+
+	_, err = CombineBytes([]ByteShare{shares[0], other[1]}, field)
+	if err == nil {
+		t.Errorf("Expected error if shares of different secrets given; got none")
+	}
+
+	_, err = CombineBytes([]ByteShare{shares[0], shares[0]}, field)
+	if err == nil {
+		t.Errorf("Expected error if duplicate shares given; got none")
+	}
+
+	for _, length := range []int{-1, 0, 1 << 40, int(^uint(0) >> 1)} {
+		invalid := []ByteShare{shares[0], shares[1]}
+		for i := range invalid {
+			invalid[i].Length = length
+		}
+
+		_, err = CombineBytes(invalid, field)
+		if err == nil {
+			t.Errorf("Expected error if shares have length %d; got none", length)
+		}
+	}
+}
+
+func benchmarkSplitCombine(b *testing.B, field gf.Field) {
+	secret := make([]byte, 4096)
+
+	for i := 0; i < b.N; i++ {
+		shares, err := SplitBytes(secret, 5, 10, field)
+		if err != nil {
+			b.Fatalf("Error splitting secret: %v", err)
+		}
+
+		_, err = CombineBytes(shares[:5], field)
+		if err != nil {
+			b.Fatalf("Error combining shares: %v", err)
+		}
+	}
+}
+
+func BenchmarkSplitCombineMersenne127(b *testing.B) {
+	benchmarkSplitCombine(b, gf.Mersenne127{})
+}
+
+func BenchmarkSplitCombineMersenne127Big(b *testing.B) {
+	benchmarkSplitCombine(b, mersenne127())
+}
+
+func BenchmarkSplitCombineP256(b *testing.B) {
+	benchmarkSplitCombine(b, gf.P256Order())
+}
+
+func BenchmarkSplitCombineP256Big(b *testing.B) {
+	benchmarkSplitCombine(b, gf.GF{P: gf.P256Order().Order()})
+}
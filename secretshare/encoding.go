@@ -0,0 +1,386 @@
+package secretshare
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// ShareFormatVersion is the version of the binary share format written by
+// `Share.MarshalBinary`.
+//
+// The binary format consists of:
+// - the format version, as a single byte
+// - the kind of share, as a single byte, which is 1 for integers and 2 for bytes
+// - the threshold and share ID, as unsigned varints
+// - the secret identifier, prefixed with its length as an unsigned varint
+// - the field order and share value, as length-prefixed big-endian integers
+// - a big-endian CRC-32 (IEEE) checksum of all preceding bytes
+const ShareFormatVersion = 1
+
+// Kinds of shares in the binary share format.
+const (
+	// shareKindInteger marks a `Share` of an integer
+	shareKindInteger byte = 1
+	// shareKindBytes marks a `ByteShare` of a byte string
+	shareKindBytes byte = 2
+)
+
+// shareTextPrefix is prepended to the base64 encoding of a share by
+// `Share.MarshalText`.
+const shareTextPrefix = "secretshare:"
+
+// checksumLength is the length of the checksum appended to binary shares.
+const checksumLength = crc32.Size
+
+// MarshalBinary encodes the share, including its metadata, into the versioned
+// binary share format.
+//
+// Returns an error if the share has no value, or a negative ID or threshold.
+func (share Share) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if share.Value == nil || share.Value.Sign() < 0 {
+		return nil, fmt.Errorf("Share must have a non-negative value")
+	}
+
+	if share.ID < 0 || share.Threshold < 0 {
+		return nil, fmt.Errorf("Share ID and threshold must not be negative")
+	}
+
+	buf.WriteByte(ShareFormatVersion)
+	buf.WriteByte(shareKindInteger)
+	writeUvarint(&buf, uint64(share.Threshold))
+	writeUvarint(&buf, uint64(share.ID))
+	writeBytes(&buf, share.SecretID)
+	if share.Order == nil {
+		writeBytes(&buf, nil)
+	} else {
+		writeBytes(&buf, share.Order.Bytes())
+	}
+	writeBytes(&buf, share.Value.Bytes())
+
+	return appendChecksum(&buf), nil
+}
+
+// UnmarshalBinary decodes a share previously encoded with MarshalBinary.
+//
+// Returns an error if the encoding is of an unknown version, encodes a byte
+// share, is truncated, or its checksum does not match.
+func (share *Share) UnmarshalBinary(data []byte) error {
+	r, err := verifyChecksum(data, shareKindInteger)
+	if err != nil {
+		return err
+	}
+
+	threshold, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	secretID, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	order, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	value, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("Trailing data after encoded share")
+	}
+
+	share.ID = id
+	share.Threshold = threshold
+	share.Value = new(big.Int).SetBytes(value)
+	share.SecretID = nil
+	if len(secretID) > 0 {
+		share.SecretID = secretID
+	}
+	share.Order = nil
+	if len(order) > 0 {
+		share.Order = new(big.Int).SetBytes(order)
+	}
+
+	return nil
+}
+
+// MarshalText encodes the share as a printable string, consisting of a fixed
+// prefix followed by the base64 encoding of its binary format.
+func (share Share) MarshalText() ([]byte, error) {
+	data, err := share.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(shareTextPrefix + base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// UnmarshalText decodes a share previously encoded with MarshalText.
+//
+// Leading and trailing whitespace is ignored.
+//
+// Returns an error if the prefix is missing, or the binary share is invalid.
+func (share *Share) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	if !strings.HasPrefix(str, shareTextPrefix) {
+		return fmt.Errorf("Encoded share must start with '%s'", shareTextPrefix)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(str, shareTextPrefix))
+	if err != nil {
+		return fmt.Errorf("Invalid base64 encoding of share: %v", err)
+	}
+
+	return share.UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the byte share, including its metadata, into the
+// versioned binary share format.
+//
+// The format matches the one of `Share.MarshalBinary`, except that the length
+// of the secret in bytes, as an unsigned varint, follows the field order. It
+// is followed by the number of values as an unsigned varint, and the values as
+// big-endian integers. If the field order is known, each value is as wide as
+// the largest element of the field. Otherwise it is length-prefixed.
+//
+// Returns an error if the share is missing a value, or has a negative ID,
+// length or threshold.
+func (share ByteShare) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	width := valueWidth(share.Order)
+	for _, value := range share.Values {
+		if value == nil || value.Sign() < 0 {
+			return nil, fmt.Errorf("Share must have non-negative values")
+		}
+
+		if width > 0 && value.Cmp(share.Order) != -1 {
+			return nil, fmt.Errorf("Share value %d is not an element of the field", value)
+		}
+	}
+
+	if share.ID < 0 || share.Threshold < 0 || share.Length < 0 {
+		return nil, fmt.Errorf("Share ID, length and threshold must not be negative")
+	}
+
+	buf.WriteByte(ShareFormatVersion)
+	buf.WriteByte(shareKindBytes)
+	writeUvarint(&buf, uint64(share.Threshold))
+	writeUvarint(&buf, uint64(share.ID))
+	writeBytes(&buf, share.SecretID)
+	if share.Order == nil {
+		writeBytes(&buf, nil)
+	} else {
+		writeBytes(&buf, share.Order.Bytes())
+	}
+	writeUvarint(&buf, uint64(share.Length))
+	writeUvarint(&buf, uint64(len(share.Values)))
+	for _, value := range share.Values {
+		if width > 0 {
+			buf.Write(value.FillBytes(make([]byte, width)))
+		} else {
+			writeBytes(&buf, value.Bytes())
+		}
+	}
+
+	return appendChecksum(&buf), nil
+}
+
+// UnmarshalBinary decodes a byte share previously encoded with MarshalBinary.
+//
+// Returns an error if the encoding is of an unknown version, encodes a share of
+// an integer, is truncated, or its checksum does not match.
+func (share *ByteShare) UnmarshalBinary(data []byte) error {
+	r, err := verifyChecksum(data, shareKindBytes)
+	if err != nil {
+		return err
+	}
+
+	threshold, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	id, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	secretID, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	order, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	length, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	count, err := readInt(r)
+	if err != nil {
+		return err
+	}
+
+	// Every value takes at least one byte, which bounds the allocation
+	if count > r.Len() {
+		return fmt.Errorf("Truncated share: %d values expected, %d bytes remaining", count, r.Len())
+	}
+
+	var fieldOrder *big.Int
+	if len(order) > 0 {
+		fieldOrder = new(big.Int).SetBytes(order)
+	}
+	width := valueWidth(fieldOrder)
+
+	values := make([]*big.Int, count)
+	for i := range values {
+		var value []byte
+		if width > 0 {
+			value = make([]byte, width)
+			if _, err := io.ReadFull(r, value); err != nil {
+				return fmt.Errorf("Truncated share: %v", err)
+			}
+		} else {
+			value, err = readBytes(r)
+			if err != nil {
+				return err
+			}
+		}
+		values[i] = new(big.Int).SetBytes(value)
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("Trailing data after encoded share")
+	}
+
+	share.ID = id
+	share.Threshold = threshold
+	share.Length = length
+	share.Values = values
+	share.Order = fieldOrder
+	share.SecretID = nil
+	if len(secretID) > 0 {
+		share.SecretID = secretID
+	}
+
+	return nil
+}
+
+// valueWidth returns the number of bytes required to encode the largest
+// element of a field of the given order, or 0 if the order is unknown.
+func valueWidth(order *big.Int) int {
+	if order == nil || order.Sign() <= 0 {
+		return 0
+	}
+
+	var max = &big.Int{}
+	max.Sub(order, big.NewInt(1))
+
+	return (max.BitLen() + 7) / 8
+}
+
+// appendChecksum appends the checksum of the contents of `buf` to it.
+//
+// Returns the contents of `buf`, including the checksum.
+func appendChecksum(buf *bytes.Buffer) []byte {
+	checksum := crc32.ChecksumIEEE(buf.Bytes())
+	binary.Write(buf, binary.BigEndian, checksum)
+
+	return buf.Bytes()
+}
+
+// verifyChecksum verifies the format version, kind and checksum of an encoded
+// share.
+//
+// Returns a reader positioned after the kind, which covers the encoded share up
+// to the checksum.
+func verifyChecksum(data []byte, kind byte) (*bytes.Reader, error) {
+	if len(data) < 2+checksumLength {
+		return nil, fmt.Errorf("Encoded share too short")
+	}
+
+	if data[0] != ShareFormatVersion {
+		return nil, fmt.Errorf("Unsupported share format version %d", data[0])
+	}
+
+	if data[1] != kind {
+		return nil, fmt.Errorf("Encoded share is of kind %d; expected %d", data[1], kind)
+	}
+
+	body := data[:len(data)-checksumLength]
+	checksum := binary.BigEndian.Uint32(data[len(data)-checksumLength:])
+	if crc32.ChecksumIEEE(body) != checksum {
+		return nil, fmt.Errorf("Share checksum mismatch")
+	}
+
+	return bytes.NewReader(body[2:]), nil
+}
+
+// writeUvarint writes an unsigned varint to `buf`.
+func writeUvarint(buf *bytes.Buffer, x uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], x)
+	buf.Write(tmp[:n])
+}
+
+// writeBytes writes a length-prefixed byte slice to `buf`.
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	writeUvarint(buf, uint64(len(b)))
+	buf.Write(b)
+}
+
+// readInt reads an unsigned varint which must fit into an int from `r`.
+func readInt(r *bytes.Reader) (int, error) {
+	x, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("Truncated share: %v", err)
+	}
+
+	if x > uint64(^uint(0)>>1) {
+		return 0, fmt.Errorf("Value %d out of range", x)
+	}
+
+	return int(x), nil
+}
+
+// readBytes reads a length-prefixed byte slice from `r`.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > r.Len() {
+		return nil, fmt.Errorf("Truncated share: %d bytes expected, %d remaining", length, r.Len())
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("Truncated share: %v", err)
+	}
+
+	return b, nil
+}
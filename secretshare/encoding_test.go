@@ -0,0 +1,279 @@
+package secretshare
+
+import (
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShareMarshalBinary(t *testing.T) {
+	field := mersenne127()
+
+	shares, _, err := TOutOfN(big.NewInt(42), 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	decoded := make([]Share, len(shares))
+	for i, share := range shares {
+		data, err := share.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Error encoding share: %v", err)
+		}
+
+		if data[0] != ShareFormatVersion {
+			t.Errorf("Expected format version %d; got %d", ShareFormatVersion, data[0])
+		}
+
+		err = decoded[i].UnmarshalBinary(data)
+		if err != nil {
+			t.Fatalf("Error decoding share: %v", err)
+		}
+
+		if !reflect.DeepEqual(share, decoded[i]) {
+			t.Errorf("Expected decoded share %v to match %v", decoded[i], share)
+		}
+	}
+
+	reconstructed, err := TOutOfNRecover(decoded[2:], field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if reconstructed.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, 42)
+	}
+
+	// Shares without metadata
+	share := Share{ID: 3, Value: big.NewInt(0)}
+	data, err := share.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+	var plain Share
+	err = plain.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("Error decoding share: %v", err)
+	}
+	if !reflect.DeepEqual(share, plain) {
+		t.Errorf("Expected decoded share %v to match %v", plain, share)
+	}
+}
+
+func TestShareMarshalBinaryInvalidInputs(t *testing.T) {
+	_, err := Share{ID: 1}.MarshalBinary()
+	if err == nil {
+		t.Errorf("Expected error when encoding share without value; got none")
+	}
+
+	_, err = Share{ID: -1, Value: big.NewInt(1)}.MarshalBinary()
+	if err == nil {
+		t.Errorf("Expected error when encoding share with negative ID; got none")
+	}
+}
+
+func TestShareUnmarshalBinaryInvalidInputs(t *testing.T) {
+	share := Share{ID: 2, Value: big.NewInt(1234), Threshold: 3, SecretID: []byte{1, 2, 3}, Order: big.NewInt(1019)}
+	data, err := share.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+
+	var decoded Share
+
+	err = decoded.UnmarshalBinary(data[:3])
+	if err == nil {
+		t.Errorf("Expected error when decoding truncated share; got none")
+	}
+
+	corrupted := append([]byte{}, data...)
+	corrupted[4] ^= 0x01
+	err = decoded.UnmarshalBinary(corrupted)
+	if err == nil {
+		t.Errorf("Expected error when decoding share with checksum mismatch; got none")
+	}
+
+	version := append([]byte{}, data...)
+	version[0] = ShareFormatVersion + 1
+	err = decoded.UnmarshalBinary(version)
+	if err == nil {
+		t.Errorf("Expected error when decoding share of unknown version; got none")
+	}
+
+	var byteShare ByteShare
+	err = byteShare.UnmarshalBinary(data)
+	if err == nil {
+		t.Errorf("Expected error when decoding share of integer as byte share; got none")
+	}
+}
+
+func TestShareMarshalText(t *testing.T) {
+	share := Share{ID: 2, Value: big.NewInt(1234), Threshold: 3, SecretID: []byte{1, 2, 3}, Order: big.NewInt(1019)}
+
+	text, err := share.MarshalText()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+
+	if !strings.HasPrefix(string(text), shareTextPrefix) {
+		t.Errorf("Expected text encoding to start with '%s'; got '%s'", shareTextPrefix, text)
+	}
+
+	var decoded Share
+	err = decoded.UnmarshalText(append(text, '\n'))
+	if err != nil {
+		t.Fatalf("Error decoding share: %v", err)
+	}
+	if !reflect.DeepEqual(share, decoded) {
+		t.Errorf("Expected decoded share %v to match %v", decoded, share)
+	}
+
+	err = decoded.UnmarshalText(text[len(shareTextPrefix):])
+	if err == nil {
+		t.Errorf("Expected error when decoding share without prefix; got none")
+	}
+
+	err = decoded.UnmarshalText([]byte(shareTextPrefix + "!!"))
+	if err == nil {
+		t.Errorf("Expected error when decoding share with invalid base64; got none")
+	}
+}
+
+func TestByteShareMarshalBinary(t *testing.T) {
+	field := mersenne127()
+	secret := []byte("a secret of more than fifteen bytes")
+
+	shares, err := SplitBytes(secret, 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+
+	decoded := make([]ByteShare, len(shares))
+	for i, share := range shares {
+		data, err := share.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Error encoding share: %v", err)
+		}
+
+		err = decoded[i].UnmarshalBinary(data)
+		if err != nil {
+			t.Fatalf("Error decoding share: %v", err)
+		}
+
+		if !reflect.DeepEqual(share, decoded[i]) {
+			t.Errorf("Expected decoded share %v to match %v", decoded[i], share)
+		}
+	}
+
+	reconstructed, err := CombineBytes(decoded[1:], field)
+	if err != nil {
+		t.Fatalf("Error combining shares: %v", err)
+	}
+	if string(reconstructed) != string(secret) {
+		t.Errorf("Reconstructed secret %x does not match %x", reconstructed, secret)
+	}
+
+	data, err := shares[0].MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+	var share ByteShare
+	err = share.UnmarshalBinary(data[:len(data)-1])
+	if err == nil {
+		t.Errorf("Expected error when decoding truncated share; got none")
+	}
+
+	var integerShare Share
+	err = integerShare.UnmarshalBinary(data)
+	if err == nil {
+		t.Errorf("Expected error when decoding byte share as share of integer; got none")
+	}
+
+	_, err = ByteShare{ID: 1, Values: []*big.Int{nil}}.MarshalBinary()
+	if err == nil {
+		t.Errorf("Expected error when encoding share without value; got none")
+	}
+
+	_, err = ByteShare{ID: 1, Values: []*big.Int{big.NewInt(256)}, Order: big.NewInt(256)}.MarshalBinary()
+	if err == nil {
+		t.Errorf("Expected error when encoding share with value outside of field; got none")
+	}
+
+	// Without field order, values are length-prefixed
+	share = ByteShare{ID: 1, Length: 2, Values: []*big.Int{big.NewInt(1000), big.NewInt(0)}}
+	data, err = share.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+	var plain ByteShare
+	err = plain.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("Error decoding share: %v", err)
+	}
+	if !reflect.DeepEqual(share, plain) {
+		t.Errorf("Expected decoded share %v to match %v", plain, share)
+	}
+}
+
+func TestByteShareMarshalBinaryGF256(t *testing.T) {
+	secret := make([]byte, 32)
+
+	shares, err := SplitBytes(secret, 2, 3, gf.GF256{})
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+
+	data, err := shares[0].MarshalBinary()
+	if err != nil {
+		t.Fatalf("Error encoding share: %v", err)
+	}
+
+	// One byte per byte of the secret, plus metadata and checksum
+	overhead := 1 + 1 + 1 + 1 + (1 + SecretIDLength) + (1 + 2) + 1 + 1 + 4
+	if len(data) != len(secret)+overhead {
+		t.Errorf("Expected encoded share of %d bytes; got %d", len(secret)+overhead, len(data))
+	}
+}
+
+func TestRecoverMismatchingMetadata(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, _, err := TOutOfN(big.NewInt(42), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+	other, _, err := TOutOfN(big.NewInt(42), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	_, err = TOutOfNRecover([]Share{shares[0], other[1]}, field)
+	if err == nil {
+		t.Errorf("Expected error if shares of different secrets given; got none")
+	}
+
+	_, err = TOutOfNRecover(shares[:2], gf.GF{P: big.NewInt(1031)})
+	if err == nil {
+		t.Errorf("Expected error if shares of different field given; got none")
+	}
+
+	_, _, err = TOutOfNRecoverRobust([]Share{shares[0], shares[1], other[2]}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if shares of different secrets given; got none")
+	}
+
+	byteShares, err := SplitBytes([]byte("secret"), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+	otherByteShares, err := SplitBytes([]byte("secret"), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error splitting secret: %v", err)
+	}
+
+	_, err = CombineBytes([]ByteShare{byteShares[0], otherByteShares[1]}, field)
+	if err == nil {
+		t.Errorf("Expected error if byte shares of different secrets given; got none")
+	}
+}
@@ -0,0 +1,193 @@
+package secretshare
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// EnrollmentPiece is a blinded piece of a new party's share, exchanged during
+// enrollment.
+//
+// In the first round, a piece is sent from one helper to another. In the
+// second round, each helper sends the sum of the pieces it received to the
+// new party.
+type EnrollmentPiece struct {
+	// ID of the helper which created the piece
+	From int
+	// ID of the helper the piece is destined for, or the ID of the new
+	// party in the second round
+	To int
+	// ID of the new party
+	NewID int
+	Value *big.Int
+	// Identifier of the shared secret, or nil if unknown
+	SecretID []byte
+}
+
+// EnrollmentContribution creates a helper's contribution to issuing a share
+// for a new party with ID `newID`, without reconstructing the secret.
+//
+// The new share is `p(k) = Sum for i in helpers [ l_i(k) * s_i ]`. Helper `i`
+// splits its Lagrange-weighted piece `l_i(k) * s_i` into one random additive
+// piece per helper, such that no single piece reveals anything about its
+// share. The pieces are sent to the respective helpers, which sum them up using
+// `EnrollmentAggregate`.
+//
+// It is required that:
+// - exactly `t` helpers take part, including the calling shareholder
+// - helper IDs and the new ID are unique, non-zero elements of the field
+//
+// Returns one piece for each helper, including the calling shareholder.
+// An error is returned if any of the requirements are violated.
+func EnrollmentContribution(share Share, helperIDs []int, newID int, field gf.Field) ([]EnrollmentPiece, error) {
+	pieces := make([]EnrollmentPiece, len(helperIDs))
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return pieces, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+	}
+
+	if share.Threshold != 0 && len(helperIDs) != share.Threshold {
+		return pieces, fmt.Errorf("%w: exactly %d helpers required; got %d", ErrThreshold, share.Threshold, len(helperIDs))
+	}
+
+	// The new ID must not be taken by a helper either
+	if err := checkIDs(append([]int{newID}, helperIDs...), field); err != nil {
+		return pieces, err
+	}
+
+	j := -1
+	xs := make([]*big.Int, len(helperIDs))
+	for i, id := range helperIDs {
+		if id == share.ID {
+			j = i
+		}
+		xs[i] = big.NewInt(int64(id))
+	}
+
+	if j == -1 {
+		return pieces, fmt.Errorf("Share with ID %d is not among the helpers", share.ID)
+	}
+
+	basePoly := gf.BasePolynomialAt(j, xs, big.NewInt(int64(newID)), field) // l_j(k)
+	weighted := field.Mul(share.Value, basePoly)                            // l_j(k) * s_j
+
+	// All pieces but the last are random, the last one ensures they sum up
+	// to the weighted share.
+	rest := weighted
+	for i, id := range helperIDs {
+		value := rest
+		if i < len(helperIDs)-1 {
+			rnd, err := field.Rand()
+			if err != nil {
+				return pieces, err
+			}
+
+			value = rnd
+			rest = field.Sub(rest, rnd)
+		}
+
+		pieces[i] = EnrollmentPiece{
+			From:     share.ID,
+			To:       id,
+			NewID:    newID,
+			Value:    value,
+			SecretID: share.SecretID,
+		}
+	}
+
+	return pieces, nil
+}
+
+// EnrollmentAggregate sums up the pieces a helper received from all helpers
+// during enrollment.
+//
+// The sum is a random value which reveals nothing about any single helper's
+// share, and is sent to the new party, which completes enrollment using
+// `EnrollmentFinish`.
+//
+// Returns an error if the pieces are not destined for the helper, do not
+// stem from unique helpers, or belong to different enrollments.
+func EnrollmentAggregate(helperID int, pieces []EnrollmentPiece, field gf.Field) (EnrollmentPiece, error) {
+	var sum EnrollmentPiece
+
+	value, err := sumPieces(pieces, helperID, field)
+	if err != nil {
+		return sum, err
+	}
+
+	sum = EnrollmentPiece{
+		From:     helperID,
+		To:       pieces[0].NewID,
+		NewID:    pieces[0].NewID,
+		Value:    value,
+		SecretID: pieces[0].SecretID,
+	}
+
+	return sum, nil
+}
+
+// EnrollmentFinish combines the aggregated pieces of all helpers into the new
+// party's share.
+//
+// As exactly `t` helpers take part, the threshold of the new share is the
+// number of pieces.
+//
+// Returns an error if the pieces are not destined for the new party, do not
+// stem from unique helpers, or belong to different enrollments.
+func EnrollmentFinish(pieces []EnrollmentPiece, field gf.Field) (Share, error) {
+	var share Share
+
+	if len(pieces) == 0 {
+		return share, fmt.Errorf("No pieces supplied")
+	}
+
+	value, err := sumPieces(pieces, pieces[0].NewID, field)
+	if err != nil {
+		return share, err
+	}
+
+	share = Share{
+		ID:        pieces[0].NewID,
+		Value:     value,
+		Threshold: len(pieces),
+		SecretID:  pieces[0].SecretID,
+		Order:     field.Order(),
+	}
+
+	return share, nil
+}
+
+// sumPieces sums up enrollment pieces destined for ID `to`.
+//
+// Returns an error if any of the pieces is destined for a different ID, if
+// they do not stem from unique helpers, or belong to different enrollments.
+func sumPieces(pieces []EnrollmentPiece, to int, field gf.Field) (*big.Int, error) {
+	var sum = &big.Int{}
+
+	if len(pieces) == 0 {
+		return sum, fmt.Errorf("No pieces supplied")
+	}
+
+	first := pieces[0]
+	seen := make(map[int]bool)
+	for _, piece := range pieces {
+		if piece.To != to {
+			return sum, fmt.Errorf("Piece from ID %d is destined for ID %d; expected %d", piece.From, piece.To, to)
+		}
+
+		if piece.NewID != first.NewID || !bytes.Equal(piece.SecretID, first.SecretID) {
+			return sum, fmt.Errorf("Piece from ID %d belongs to a different enrollment", piece.From)
+		}
+
+		if _, ok := seen[piece.From]; ok {
+			return sum, fmt.Errorf("Duplicate piece from ID %d supplied", piece.From)
+		}
+		seen[piece.From] = true
+
+		sum = field.Add(sum, piece.Value)
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,141 @@
+package secretshare
+
+import (
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func TestEnrollment(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+	newID := 7
+
+	shares, pol, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	helpers := []Share{shares[1], shares[3], shares[4]}
+	helperIDs := []int{2, 4, 5}
+
+	// Round 1: each helper sends one piece to every helper
+	received := make(map[int][]EnrollmentPiece)
+	for _, helper := range helpers {
+		pieces, err := EnrollmentContribution(helper, helperIDs, newID, field)
+		if err != nil {
+			t.Fatalf("Error creating enrollment contribution: %v", err)
+		}
+
+		for _, piece := range pieces {
+			received[piece.To] = append(received[piece.To], piece)
+		}
+	}
+
+	// Round 2: each helper sends the sum of its pieces to the new party
+	sums := make([]EnrollmentPiece, len(helperIDs))
+	for i, id := range helperIDs {
+		sums[i], err = EnrollmentAggregate(id, received[id], field)
+		if err != nil {
+			t.Fatalf("Error aggregating enrollment pieces: %v", err)
+		}
+	}
+
+	share, err := EnrollmentFinish(sums, field)
+	if err != nil {
+		t.Fatalf("Error finishing enrollment: %v", err)
+	}
+
+	expected, err := pol.Evaluate(big.NewInt(int64(newID)))
+	if err != nil {
+		t.Fatalf("Error evaluating polynomial: %v", err)
+	}
+	if share.ID != newID || share.Value.Cmp(expected) != 0 {
+		t.Errorf("Expected new share p(%d) = %d; got p(%d) = %d", newID, expected, share.ID, share.Value)
+	}
+
+	// The new share may be used for recovery alongside old ones
+	reconstructed, err := TOutOfNRecover([]Share{share, shares[0], shares[2]}, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestEnrollmentContributionInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+	share := Share{ID: 1, Value: big.NewInt(10), Threshold: 2}
+
+	_, err := EnrollmentContribution(share, []int{1, 2, 3}, 4, field)
+	if err == nil {
+		t.Errorf("Expected error if number of helpers does not match threshold; got none")
+	}
+
+	_, err = EnrollmentContribution(share, []int{2, 3}, 4, field)
+	if err == nil {
+		t.Errorf("Expected error if share is not among helpers; got none")
+	}
+
+	_, err = EnrollmentContribution(share, []int{1, 2}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if new ID is taken by a helper; got none")
+	}
+
+	_, err = EnrollmentContribution(share, []int{1, 1}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if helper IDs are not unique; got none")
+	}
+
+	_, err = EnrollmentContribution(share, []int{1, 2}, 0, field)
+	if err == nil {
+		t.Errorf("Expected error if new ID is zero; got none")
+	}
+
+	_, err = EnrollmentContribution(share, []int{1, 2}, 53, field)
+	if err == nil {
+		t.Errorf("Expected error if new ID is not a group element; got none")
+	}
+}
+
+func TestEnrollmentAggregateInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	_, err := EnrollmentAggregate(1, []EnrollmentPiece{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no pieces given; got none")
+	}
+
+	pieces := []EnrollmentPiece{
+		{From: 1, To: 1, NewID: 4, Value: big.NewInt(1)},
+		{From: 2, To: 2, NewID: 4, Value: big.NewInt(1)},
+	}
+	_, err = EnrollmentAggregate(1, pieces, field)
+	if err == nil {
+		t.Errorf("Expected error if piece for different helper given; got none")
+	}
+
+	pieces = []EnrollmentPiece{
+		{From: 1, To: 1, NewID: 4, Value: big.NewInt(1)},
+		{From: 1, To: 1, NewID: 4, Value: big.NewInt(1)},
+	}
+	_, err = EnrollmentAggregate(1, pieces, field)
+	if err == nil {
+		t.Errorf("Expected error if duplicate pieces given; got none")
+	}
+
+	pieces = []EnrollmentPiece{
+		{From: 1, To: 4, NewID: 4, Value: big.NewInt(1)},
+		{From: 2, To: 4, NewID: 5, Value: big.NewInt(1)},
+	}
+	_, err = EnrollmentFinish(pieces, field)
+	if err == nil {
+		t.Errorf("Expected error if pieces of different enrollments given; got none")
+	}
+
+	_, err = EnrollmentFinish([]EnrollmentPiece{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no pieces given; got none")
+	}
+}
@@ -0,0 +1,26 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+)
+
+// Errors returned by this package, which may be wrapped with additional
+// context. Use `errors.Is` to check for them.
+var (
+	// ErrThreshold is returned if a threshold is out of range, or too few
+	// shares are supplied to meet it.
+	ErrThreshold = errors.New("Invalid threshold")
+	// ErrDuplicateID is returned if share IDs are not unique.
+	ErrDuplicateID = errors.New("Duplicate share ID")
+	// ErrZeroID is returned if a share ID is zero, as the share would be
+	// the secret itself.
+	ErrZeroID = errors.New("Share ID must not be zero")
+	// ErrNotInField is returned if a share ID, share value or secret is not
+	// an element of the field. It is the same as `gf.ErrNotInField`.
+	ErrNotInField = gf.ErrNotInField
+	// ErrFieldTooSmall is returned if a field has too few elements for the
+	// number of shares, or to hold the secret. It is the same as
+	// `gf.ErrFieldTooSmall`.
+	ErrFieldTooSmall = gf.ErrFieldTooSmall
+)
@@ -0,0 +1,113 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// FeldmanCommitments are the public commitments `g^{a_i}` to the coefficients
+// `a_i` of the dealer's polynomial, as used by Feldman's verifiable secret
+// sharing.
+type FeldmanCommitments struct {
+	// Group the commitments are in
+	Group gf.SchnorrGroup
+	// Commitments to the coefficients, ordered from the lowest degree to
+	// the highest
+	Values []*big.Int
+}
+
+// Threshold returns the number of shares required to recover the secret.
+func (commitments FeldmanCommitments) Threshold() int {
+	return len(commitments.Values)
+}
+
+// FeldmanTOutOfN implements t-out-of-n verifiable secret sharing as proposed
+// by Feldman.
+//
+// The secret is shared using `TOutOfN` over the scalar field GF(q) of the
+// group, and the dealer publishes commitments to each coefficient of the
+// polynomial, which allow each party to verify its share using `VerifyShare`.
+//
+// Note that the commitment `g^{a_0}` reveals `g^secret`, so the secret should
+// be of high entropy.
+//
+// Returns a slice containing the shares and the commitments to the polynomial
+// used to calculate the shares.
+// An error is returned if any of the requirements of `TOutOfN` are violated.
+func FeldmanTOutOfN(secret *big.Int, t int, n int, group gf.SchnorrGroup) ([]Share, FeldmanCommitments, error) {
+	commitments := FeldmanCommitments{Group: group}
+
+	shares, pol, err := TOutOfN(secret, t, n, group.ScalarField())
+	if err != nil {
+		return shares, commitments, err
+	}
+
+	commitments.Values = make([]*big.Int, len(pol.Coefficients))
+	for i, coef := range pol.Coefficients {
+		commitments.Values[i] = group.Commit(coef) // g^{a_i}
+	}
+
+	return shares, commitments, nil
+}
+
+// VerifyShare verifies that a share is consistent with the commitments to the
+// dealer's polynomial.
+//
+// A share `s` with ID `x` is valid if:
+// `g^s = Product for i = 0 to t-1 [ C_i^(x^i) ]`
+func VerifyShare(share Share, commitments FeldmanCommitments) bool {
+	group := commitments.Group
+	field := group.ScalarField()
+
+	if len(commitments.Values) == 0 || share.ID < 1 {
+		return false
+	}
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return false
+	}
+
+	x := big.NewInt(int64(share.ID))
+	expected := big.NewInt(1)
+	for i, commitment := range commitments.Values {
+		if !group.IsElement(commitment) {
+			return false
+		}
+
+		exp := field.Exp(x, big.NewInt(int64(i))) // x^i mod q
+		term := group.Exp(commitment, exp)        // C_i^(x^i)
+		expected = group.Mul(expected, term)
+	}
+
+	return group.Commit(share.Value).Cmp(expected) == 0
+}
+
+// FeldmanRecover recovers a secret from shares created by `FeldmanTOutOfN`.
+//
+// Every supplied share is verified against the commitments. Any number of at
+// least `t` unique shares may be supplied, of which the first `t` are used for
+// recovery.
+//
+// Returns an error if any share fails verification, if shares are not unique,
+// or if fewer than `t` shares are supplied.
+func FeldmanRecover(shares []Share, commitments FeldmanCommitments) (*big.Int, error) {
+	var secret = &big.Int{}
+
+	var invalid []int
+	for _, share := range shares {
+		if !VerifyShare(share, commitments) {
+			invalid = append(invalid, share.ID)
+		}
+	}
+	if len(invalid) > 0 {
+		return secret, fmt.Errorf("Shares with IDs %v failed verification", invalid)
+	}
+
+	t := commitments.Threshold()
+	if len(shares) < t {
+		return secret, fmt.Errorf("%w: at least %d shares required; got %d", ErrThreshold, t, len(shares))
+	}
+
+	return TOutOfNRecover(shares[:t], commitments.Group.ScalarField())
+}
@@ -0,0 +1,108 @@
+package secretshare
+
+import (
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+// testGroup returns the Schnorr group of order 1019 in Z*_2039.
+func testGroup() gf.SchnorrGroup {
+	return gf.SchnorrGroup{
+		P: big.NewInt(2039),
+		Q: big.NewInt(1019),
+		G: big.NewInt(4),
+	}
+}
+
+func TestFeldmanTOutOfN(t *testing.T) {
+	group := testGroup()
+	secret := big.NewInt(42)
+
+	shares, commitments, err := FeldmanTOutOfN(secret, 3, 5, group)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	if commitments.Threshold() != 3 {
+		t.Errorf("Expected 3 commitments; got %d", commitments.Threshold())
+	}
+
+	if commitments.Values[0].Cmp(group.Commit(secret)) != 0 {
+		t.Errorf("Expected first commitment to be g^secret = %d; got %d", group.Commit(secret), commitments.Values[0])
+	}
+
+	for _, share := range shares {
+		if !VerifyShare(share, commitments) {
+			t.Errorf("Expected share %d to pass verification; did not", share.ID)
+		}
+	}
+
+	// Any superset of t shares is accepted
+	reconstructed, err := FeldmanRecover([]Share{shares[4], shares[1], shares[2], shares[0]}, commitments)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestVerifyShare(t *testing.T) {
+	group := testGroup()
+
+	shares, commitments, err := FeldmanTOutOfN(big.NewInt(42), 3, 5, group)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	tampered := Share{ID: shares[0].ID, Value: group.ScalarField().Add(shares[0].Value, big.NewInt(1))}
+	if VerifyShare(tampered, commitments) {
+		t.Errorf("Expected tampered share to fail verification; did not")
+	}
+
+	swapped := Share{ID: shares[1].ID, Value: shares[0].Value}
+	if shares[0].Value.Cmp(shares[1].Value) != 0 && VerifyShare(swapped, commitments) {
+		t.Errorf("Expected share with wrong ID to fail verification; did not")
+	}
+
+	outOfField := Share{ID: shares[0].ID, Value: big.NewInt(1019)}
+	if VerifyShare(outOfField, commitments) {
+		t.Errorf("Expected share not in scalar field to fail verification; did not")
+	}
+
+	// A malicious dealer publishing commitments to a different polynomial
+	inconsistent := FeldmanCommitments{Group: group, Values: append([]*big.Int{}, commitments.Values...)}
+	inconsistent.Values[1] = group.Mul(inconsistent.Values[1], group.G)
+	for _, share := range shares {
+		if VerifyShare(share, inconsistent) {
+			t.Errorf("Expected share %d to fail verification against inconsistent commitments; did not", share.ID)
+		}
+	}
+
+	notInGroup := FeldmanCommitments{Group: group, Values: append([]*big.Int{}, commitments.Values...)}
+	notInGroup.Values[2] = big.NewInt(0)
+	if VerifyShare(shares[0], notInGroup) {
+		t.Errorf("Expected verification against commitment not in group to fail; did not")
+	}
+}
+
+func TestFeldmanRecoverInvalidInputs(t *testing.T) {
+	group := testGroup()
+
+	shares, commitments, err := FeldmanTOutOfN(big.NewInt(42), 3, 5, group)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	_, err = FeldmanRecover(shares[:2], commitments)
+	if err == nil {
+		t.Errorf("Expected error if fewer than t shares given; got none")
+	}
+
+	tampered := Share{ID: shares[0].ID, Value: group.ScalarField().Add(shares[0].Value, big.NewInt(1))}
+	_, err = FeldmanRecover([]Share{tampered, shares[1], shares[2]}, commitments)
+	if err == nil {
+		t.Errorf("Expected error if tampered share given; got none")
+	}
+}
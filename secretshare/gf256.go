@@ -0,0 +1,146 @@
+package secretshare
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+)
+
+// Share256 represents a single party's share of a secret which was shared
+// byte-by-byte over GF(2^8).
+//
+// `Value` has the same length as the secret.
+type Share256 struct {
+	ID    int
+	Value []byte
+}
+
+// maxShares256 is the maximum number of shares in GF(2^8), as share IDs must
+// be unique and non-zero.
+const maxShares256 = 255
+
+// TOutOfNGF256 implements t-out-of-n secret sharing of a byte string, using
+// polynomials over GF(2^8).
+//
+// Each byte of the secret is shared with its own random polynomial, such that
+// each share is exactly as long as the secret. This is equivalent to calling
+// `SplitBytes` with `gf.GF256`, but operates on bytes directly rather than on
+// `*big.Int` values.
+//
+// It is required that:
+// - 1 < t <= n <= 255
+// - the secret is not empty
+//
+// Returns a slice containing the shares.
+// An error is returned if any of the requirements are violated.
+func TOutOfNGF256(secret []byte, t int, n int) ([]Share256, error) {
+	var field gf.GF256
+	var shares []Share256
+
+	if t <= 1 || t > n {
+		return shares, fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, n)
+	}
+
+	if n > maxShares256 {
+		return shares, fmt.Errorf("%w: at most %d shares supported; got %d", ErrFieldTooSmall, maxShares256, n)
+	}
+
+	if len(secret) == 0 {
+		return shares, fmt.Errorf("Secret must not be empty")
+	}
+
+	// Coefficients a_1 ... a_{t-1} of each byte's polynomial. a_0 is the
+	// byte of the secret itself.
+	coefs := make([]byte, len(secret)*(t-1))
+	if _, err := rand.Read(coefs); err != nil {
+		return shares, fmt.Errorf("Error generating random polynomials: %v", err)
+	}
+
+	shares = make([]Share256, n)
+	for i := 0; i < n; i++ {
+		// Share of participant `i` will be p(i)
+		x := byte(i + 1)
+		value := make([]byte, len(secret))
+
+		for b := range secret {
+			pol := coefs[b*(t-1) : (b+1)*(t-1)]
+
+			// Horner's method, starting at the highest coefficient
+			var y byte
+			for k := len(pol) - 1; k >= 0; k-- {
+				y = field.AddByte(field.MulByte(y, x), pol[k])
+			}
+			value[b] = field.AddByte(field.MulByte(y, x), secret[b])
+		}
+
+		shares[i] = Share256{ID: int(x), Value: value}
+	}
+
+	return shares, nil
+}
+
+// TOutOfNGF256Recover recovers a secret from t out of n shares created by
+// `TOutOfNGF256`.
+//
+// The slice of shares must be *exactly* `t` *unique* shares. If there are any
+// more or less, an incorrect value will be reconstructed.
+//
+// Returns an error if shares are not unique, have invalid IDs or differ in
+// length.
+func TOutOfNGF256Recover(shares []Share256) ([]byte, error) {
+	var field gf.GF256
+	var secret []byte
+
+	if len(shares) == 0 {
+		return secret, fmt.Errorf("No shares supplied")
+	}
+
+	seen := make(map[int]bool)
+	length := len(shares[0].Value)
+	for _, share := range shares {
+		if share.ID == 0 {
+			return secret, ErrZeroID
+		}
+		if share.ID < 0 || share.ID > maxShares256 {
+			return secret, fmt.Errorf("%w: share ID %d", ErrNotInField, share.ID)
+		}
+		if _, ok := seen[share.ID]; ok {
+			// Share with given ID already seen
+			return secret, fmt.Errorf("%w %d supplied", ErrDuplicateID, share.ID)
+		}
+		seen[share.ID] = true
+
+		if len(share.Value) != length {
+			return secret, fmt.Errorf("Share with ID %d does not match secret length of %d bytes", share.ID, length)
+		}
+	}
+
+	// The Lagrange base polynomials at 0 only depend on the share IDs, so
+	// they can be shared across all bytes.
+	//   l_j(0) = Product for m != j [ x_m / (x_m - x_j) ]
+	basePolys := make([]byte, len(shares))
+	for j, sj := range shares {
+		xj := byte(sj.ID)
+		basePolys[j] = 1
+
+		for m, sm := range shares {
+			if m == j {
+				continue
+			}
+
+			xm := byte(sm.ID)
+			term := field.DivByte(xm, field.SubByte(xm, xj)) // x_m / (x_m - x_j)
+			basePolys[j] = field.MulByte(basePolys[j], term)
+		}
+	}
+
+	secret = make([]byte, length)
+	for b := range secret {
+		for j, share := range shares {
+			term := field.MulByte(share.Value[b], basePolys[j]) // y_j * l_j(0)
+			secret[b] = field.AddByte(secret[b], term)
+		}
+	}
+
+	return secret, nil
+}
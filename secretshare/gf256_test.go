@@ -0,0 +1,129 @@
+package secretshare
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestTOutOfNGF256(t *testing.T) {
+	secret := []byte{0x00, 0x00, 0x2a, 0xff, 0x10, 0x00}
+
+	// 3-out-of-5
+	shares, err := TOutOfNGF256(secret, 3, 5)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	if len(shares) != 5 {
+		t.Errorf("Expected 5 shares; got %d", len(shares))
+	}
+
+	for _, share := range shares {
+		if len(share.Value) != len(secret) {
+			t.Errorf("Expected share of length %d; got %d", len(secret), len(share.Value))
+		}
+	}
+
+	sharesSubset := []Share256{
+		shares[0],
+		shares[2],
+		shares[4],
+	}
+	reconstructed, err := TOutOfNGF256Recover(sharesSubset)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if !bytes.Equal(secret, reconstructed) {
+		t.Errorf("Reconstructed secret %x does not match %x", reconstructed, secret)
+	}
+
+	// 255-out-of-255
+	shares, err = TOutOfNGF256(secret, 255, 255)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	reconstructed, err = TOutOfNGF256Recover(shares)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if !bytes.Equal(secret, reconstructed) {
+		t.Errorf("Reconstructed secret %x does not match %x", reconstructed, secret)
+	}
+}
+
+func TestTOutOfNGF256InvalidInputs(t *testing.T) {
+	secret := []byte("secret")
+
+	_, err := TOutOfNGF256(secret, 1, 5)
+	if err == nil {
+		t.Errorf("Expected error if t <= 1; got none")
+	}
+
+	_, err = TOutOfNGF256(secret, 6, 5)
+	if err == nil {
+		t.Errorf("Expected error if t > n; got none")
+	}
+
+	_, err = TOutOfNGF256(secret, 1, -1)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n < 0; got %v", err)
+	}
+
+	_, err = TOutOfNGF256(secret, 3, 256)
+	if !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected ErrFieldTooSmall if n > 255; got %v", err)
+	}
+
+	_, err = TOutOfNGF256([]byte{}, 3, 5)
+	if err == nil {
+		t.Errorf("Expected error if secret is empty; got none")
+	}
+}
+
+func TestTOutOfNGF256Recover(t *testing.T) {
+	// p(x) = 0x02 x + 0x2a, so p(1) = 0x28, p(2) = 0x2e
+	shares := []Share256{
+		{1, []byte{0x28}},
+		{2, []byte{0x2e}},
+	}
+	actual, err := TOutOfNGF256Recover(shares)
+	if err != nil {
+		t.Fatalf("Error while recovering secret: %v", err)
+	}
+	if !bytes.Equal(actual, []byte{0x2a}) {
+		t.Errorf("Expected to recover %x; got %x", []byte{0x2a}, actual)
+	}
+}
+
+func TestTOutOfNGF256RecoverInvalidInputs(t *testing.T) {
+	_, err := TOutOfNGF256Recover([]Share256{})
+	if err == nil {
+		t.Errorf("Expected error if no shares given; got none")
+	}
+
+	_, err = TOutOfNGF256Recover([]Share256{
+		{1, []byte{0x10}},
+		{1, []byte{0x11}},
+	})
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if duplicate shares given; got %v", err)
+	}
+
+	_, err = TOutOfNGF256Recover([]Share256{
+		{0, []byte{0x10}},
+		{1, []byte{0x11}},
+	})
+	if !errors.Is(err, ErrZeroID) {
+		t.Errorf("Expected ErrZeroID if share with ID 0 given; got %v", err)
+	}
+
+	_, err = TOutOfNGF256Recover([]Share256{
+		{1, []byte{0x10}},
+		{2, []byte{0x11, 0x12}},
+	})
+	if err == nil {
+		t.Errorf("Expected error if shares of different length given; got none")
+	}
+}
@@ -0,0 +1,217 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// PedersenParams are the public parameters of Pedersen's verifiable secret
+// sharing: a Schnorr group with generator `g`, and a second generator `h` of
+// which nobody knows the discrete logarithm with respect to `g`.
+type PedersenParams struct {
+	Group gf.SchnorrGroup
+	H     *big.Int
+}
+
+// NewPedersenParams creates Pedersen parameters for the given group, deriving
+// `h` from a public seed using `gf.SchnorrGroup.DeriveGenerator`.
+//
+// As `h` is derived by hashing, anyone may check that it was not chosen with a
+// known discrete logarithm by repeating the derivation. If the group has no
+// such generator, `h` is nil, which `Validate` rejects.
+func NewPedersenParams(group gf.SchnorrGroup, seed []byte) PedersenParams {
+	return PedersenParams{Group: group, H: group.DeriveGenerator(seed)}
+}
+
+// Validate checks that the group has order at least 3, and that `h` is an
+// element of the group other than 1 and `g`.
+//
+// With `h = 1`, commitments do not hide the committed value, and with `h = g`,
+// the discrete logarithm of h is known, so commitments are not binding.
+//
+// Returns an error if any of the requirements are violated.
+func (params PedersenParams) Validate() error {
+	if params.Group.Q == nil || params.Group.Q.Cmp(big.NewInt(3)) < 0 {
+		return fmt.Errorf("Group order must be at least 3; got %d", params.Group.Q)
+	}
+
+	if params.H == nil || !params.Group.IsElement(params.H) {
+		return fmt.Errorf("%w: %d", ErrNotInField, params.H)
+	}
+
+	if params.H.Cmp(big.NewInt(1)) == 0 || params.H.Cmp(params.Group.G) == 0 {
+		return fmt.Errorf("Second generator must differ from 1 and g; got %d", params.H)
+	}
+
+	return nil
+}
+
+// Commit returns the Pedersen commitment `g^a h^b` to a value `a` with
+// blinding factor `b`.
+func (params PedersenParams) Commit(a *big.Int, b *big.Int) *big.Int {
+	ga := params.Group.Exp(params.Group.G, a) // g^a
+	hb := params.Group.Exp(params.H, b)       // h^b
+
+	return params.Group.Mul(ga, hb)
+}
+
+// PedersenShare represents a single party's share of a secret shared using
+// Pedersen's verifiable secret sharing.
+type PedersenShare struct {
+	ID int
+	// Share of the secret, f(ID)
+	Value *big.Int
+	// Share of the blinding polynomial, r(ID)
+	Blinding *big.Int
+}
+
+// Share returns the plain share of the secret, discarding the blinding value.
+func (share PedersenShare) Share() Share {
+	return Share{ID: share.ID, Value: share.Value}
+}
+
+// PedersenCommitments are the public commitments `g^{a_i} h^{b_i}` to the
+// coefficients `a_i` of the dealer's polynomial, blinded with the coefficients
+// `b_i` of a second random polynomial.
+type PedersenCommitments struct {
+	// Parameters the commitments were created with
+	Params PedersenParams
+	// Commitments to the coefficients, ordered from the lowest degree to
+	// the highest
+	Values []*big.Int
+}
+
+// Threshold returns the number of shares required to recover the secret.
+func (commitments PedersenCommitments) Threshold() int {
+	return len(commitments.Values)
+}
+
+// PedersenTOutOfN implements t-out-of-n verifiable secret sharing as proposed
+// by Pedersen.
+//
+// The secret is shared using `TOutOfN` over the scalar field GF(q) of the
+// group. A second, random polynomial of the same degree is used to blind the
+// commitments, such that they reveal no information about the secret even to
+// a computationally unbounded adversary.
+//
+// Returns a slice containing the shares and the commitments to the
+// polynomials used to calculate the shares.
+// An error is returned if the parameters are invalid, see `Validate`, or if
+// any of the requirements of `TOutOfN` are violated.
+func PedersenTOutOfN(secret *big.Int, t int, n int, params PedersenParams) ([]PedersenShare, PedersenCommitments, error) {
+	commitments := PedersenCommitments{Params: params}
+	var shares []PedersenShare
+	field := params.Group.ScalarField()
+
+	if err := params.Validate(); err != nil {
+		return shares, commitments, err
+	}
+
+	plain, pol, err := TOutOfN(secret, t, n, field)
+	if err != nil {
+		return shares, commitments, err
+	}
+
+	blinding, err := gf.RandomPolynomial(t-1, field)
+	if err != nil {
+		return shares, commitments, err
+	}
+
+	shares = make([]PedersenShare, n)
+	for i, share := range plain {
+		r, err := blinding.Evaluate(big.NewInt(int64(share.ID)))
+		if err != nil {
+			return shares, commitments, err
+		}
+
+		shares[i] = PedersenShare{ID: share.ID, Value: share.Value, Blinding: r}
+	}
+
+	commitments.Values = make([]*big.Int, len(pol.Coefficients))
+	for i := range pol.Coefficients {
+		// g^{a_i} h^{b_i}
+		commitments.Values[i] = params.Commit(pol.Coefficients[i], blinding.Coefficients[i])
+	}
+
+	return shares, commitments, nil
+}
+
+// VerifyPedersenShare verifies that a share is consistent with the
+// commitments to the dealer's polynomials.
+//
+// A share `(s, r)` with ID `x` is valid if:
+// `g^s h^r = Product for i = 0 to t-1 [ C_i^(x^i) ]`
+//
+// Shares never verify against commitments with invalid parameters, see
+// `Validate`, as these do not bind the dealer to a polynomial.
+func VerifyPedersenShare(share PedersenShare, commitments PedersenCommitments) bool {
+	params := commitments.Params
+	group := params.Group
+	field := group.ScalarField()
+
+	if params.Validate() != nil {
+		return false
+	}
+
+	if len(commitments.Values) == 0 || share.ID < 1 {
+		return false
+	}
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return false
+	}
+
+	if share.Blinding == nil || !field.IsGroupElement(share.Blinding) {
+		return false
+	}
+
+	x := big.NewInt(int64(share.ID))
+	expected := big.NewInt(1)
+	for i, commitment := range commitments.Values {
+		if !group.IsElement(commitment) {
+			return false
+		}
+
+		exp := field.Exp(x, big.NewInt(int64(i))) // x^i mod q
+		term := group.Exp(commitment, exp)        // C_i^(x^i)
+		expected = group.Mul(expected, term)
+	}
+
+	return params.Commit(share.Value, share.Blinding).Cmp(expected) == 0
+}
+
+// PedersenRecover recovers a secret from shares created by
+// `PedersenTOutOfN`.
+//
+// Every supplied share is verified against the commitments. Any number of at
+// least `t` unique shares may be supplied, of which the first `t` are used for
+// recovery.
+//
+// Returns an error if any share fails verification, if shares are not unique,
+// or if fewer than `t` shares are supplied.
+func PedersenRecover(shares []PedersenShare, commitments PedersenCommitments) (*big.Int, error) {
+	var secret = &big.Int{}
+
+	var invalid []int
+	for _, share := range shares {
+		if !VerifyPedersenShare(share, commitments) {
+			invalid = append(invalid, share.ID)
+		}
+	}
+	if len(invalid) > 0 {
+		return secret, fmt.Errorf("Shares with IDs %v failed verification", invalid)
+	}
+
+	t := commitments.Threshold()
+	if len(shares) < t {
+		return secret, fmt.Errorf("%w: at least %d shares required; got %d", ErrThreshold, t, len(shares))
+	}
+
+	plain := make([]Share, t)
+	for i := 0; i < t; i++ {
+		plain[i] = shares[i].Share()
+	}
+
+	return TOutOfNRecover(plain, commitments.Params.Group.ScalarField())
+}
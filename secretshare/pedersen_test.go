@@ -0,0 +1,143 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func TestNewPedersenParams(t *testing.T) {
+	group := testGroup()
+	params := NewPedersenParams(group, []byte("pedersen"))
+
+	if err := params.Validate(); err != nil {
+		t.Errorf("Expected h = %d to be valid; got %v", params.H, err)
+	}
+
+	expected := group.Mul(group.Exp(group.G, big.NewInt(3)), group.Exp(params.H, big.NewInt(5)))
+	if params.Commit(big.NewInt(3), big.NewInt(5)).Cmp(expected) != 0 {
+		t.Errorf("Expected commitment g^3 h^5 = %d; got %d", expected, params.Commit(big.NewInt(3), big.NewInt(5)))
+	}
+
+	small := NewPedersenParams(gf.SchnorrGroup{P: big.NewInt(5), Q: big.NewInt(2), G: big.NewInt(4)}, []byte("pedersen"))
+	if err := small.Validate(); err == nil {
+		t.Errorf("Expected error for group of order 2; got none")
+	}
+}
+
+func TestPedersenTOutOfN(t *testing.T) {
+	params := NewPedersenParams(testGroup(), []byte("pedersen"))
+	secret := big.NewInt(42)
+
+	shares, commitments, err := PedersenTOutOfN(secret, 3, 5, params)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	if len(shares) != 5 {
+		t.Errorf("Expected 5 shares; got %d", len(shares))
+	}
+
+	if commitments.Threshold() != 3 {
+		t.Errorf("Expected 3 commitments; got %d", commitments.Threshold())
+	}
+
+	for _, share := range shares {
+		if !VerifyPedersenShare(share, commitments) {
+			t.Errorf("Expected share %d to pass verification; did not", share.ID)
+		}
+	}
+
+	reconstructed, err := PedersenRecover([]PedersenShare{shares[3], shares[0], shares[1], shares[4]}, commitments)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestVerifyPedersenShare(t *testing.T) {
+	params := NewPedersenParams(testGroup(), []byte("pedersen"))
+	field := params.Group.ScalarField()
+
+	shares, commitments, err := PedersenTOutOfN(big.NewInt(42), 3, 5, params)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	tampered := shares[0]
+	tampered.Value = field.Add(tampered.Value, big.NewInt(1))
+	if VerifyPedersenShare(tampered, commitments) {
+		t.Errorf("Expected share with tampered value to fail verification; did not")
+	}
+
+	tampered = shares[0]
+	tampered.Blinding = field.Add(tampered.Blinding, big.NewInt(1))
+	if VerifyPedersenShare(tampered, commitments) {
+		t.Errorf("Expected share with tampered blinding to fail verification; did not")
+	}
+
+	tampered = shares[0]
+	tampered.Blinding = nil
+	if VerifyPedersenShare(tampered, commitments) {
+		t.Errorf("Expected share without blinding to fail verification; did not")
+	}
+
+	// With h = 1, commitments are g^{a_i} and ignore the blinding
+	unhiding := PedersenCommitments{Params: PedersenParams{Group: params.Group, H: big.NewInt(1)}}
+	for _, coefficient := range []int64{42, 1, 2} {
+		unhiding.Values = append(unhiding.Values, params.Group.Exp(params.Group.G, big.NewInt(coefficient)))
+	}
+	share := PedersenShare{ID: 1, Value: big.NewInt(45), Blinding: big.NewInt(7)}
+	if VerifyPedersenShare(share, unhiding) {
+		t.Errorf("Expected share to fail verification against commitments with h = 1; did not")
+	}
+}
+
+func TestPedersenInvalidInputs(t *testing.T) {
+	params := NewPedersenParams(testGroup(), []byte("pedersen"))
+	field := params.Group.ScalarField()
+
+	shares, commitments, err := PedersenTOutOfN(big.NewInt(42), 3, 5, params)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	_, err = PedersenRecover(shares[:2], commitments)
+	if err == nil {
+		t.Errorf("Expected error if fewer than t shares given; got none")
+	}
+
+	tampered := shares[1]
+	tampered.Value = field.Add(tampered.Value, big.NewInt(1))
+	_, err = PedersenRecover([]PedersenShare{shares[0], tampered, shares[2]}, commitments)
+	if err == nil {
+		t.Errorf("Expected error if tampered share given; got none")
+	}
+
+	invalidParams := PedersenParams{Group: params.Group, H: big.NewInt(2038)}
+	_, _, err = PedersenTOutOfN(big.NewInt(42), 3, 5, invalidParams)
+	if err == nil {
+		t.Errorf("Expected error if h is not a group element; got none")
+	}
+
+	for _, h := range []*big.Int{nil, big.NewInt(1), params.Group.G} {
+		invalidParams = PedersenParams{Group: params.Group, H: h}
+		_, _, err = PedersenTOutOfN(big.NewInt(42), 3, 5, invalidParams)
+		if err == nil {
+			t.Errorf("Expected error if h = %d; got none", h)
+		}
+	}
+
+	_, _, err = PedersenTOutOfN(big.NewInt(42), 1, -1, params)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n < 0; got %v", err)
+	}
+
+	_, _, err = PedersenTOutOfN(big.NewInt(1019), 3, 5, params)
+	if err == nil {
+		t.Errorf("Expected error if secret is not in scalar field; got none")
+	}
+}
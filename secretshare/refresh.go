@@ -0,0 +1,152 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// RefreshContribution creates one party's contribution to a proactive refresh
+// of t-out-of-n shares, as proposed by Herzberg et al.
+//
+// The party deals a random polynomial `δ` of degree `t - 1` with `δ(0) = 0`,
+// and returns the sub-share `δ(id)` for each shareholder. Once every
+// shareholder has added the sub-shares of all contributions to their share
+// using `ApplyRefresh`, the old shares are useless to an adversary, while the
+// shared secret is unchanged.
+//
+// It is required that:
+// - 1 < t <= len(ids)
+// - ids are unique, non-zero elements of the field
+//
+// Returns a slice containing one sub-share for each ID.
+// An error is returned if any of the requirements are violated.
+func RefreshContribution(ids []int, t int, field gf.Field) ([]Share, error) {
+	subshares, _, err := refreshContribution(ids, t, field)
+	return subshares, err
+}
+
+// FeldmanRefreshContribution creates one party's contribution to a proactive
+// refresh of shares created by `FeldmanTOutOfN`.
+//
+// In addition to the sub-shares created by `RefreshContribution`, Feldman
+// commitments to the coefficients of the zero polynomial are returned. They
+// allow each shareholder to verify their sub-share with
+// `VerifyRefreshContribution`, and to update the commitments to the shared
+// secret with `ApplyRefreshCommitments`.
+func FeldmanRefreshContribution(ids []int, t int, group gf.SchnorrGroup) ([]Share, FeldmanCommitments, error) {
+	commitments := FeldmanCommitments{Group: group}
+
+	subshares, pol, err := refreshContribution(ids, t, group.ScalarField())
+	if err != nil {
+		return subshares, commitments, err
+	}
+
+	commitments.Values = make([]*big.Int, len(pol.Coefficients))
+	for i, coef := range pol.Coefficients {
+		commitments.Values[i] = group.Commit(coef) // g^{a_i}
+	}
+
+	return subshares, commitments, nil
+}
+
+// refreshContribution implements `RefreshContribution`, additionally
+// returning the zero polynomial used to calculate the sub-shares.
+func refreshContribution(ids []int, t int, field gf.Field) ([]Share, gf.Polynomial, error) {
+	// δ(0) = 0, so adding sub-shares does not change the secret
+	return dealShares(big.NewInt(0), t, ids, field)
+}
+
+// ApplyRefresh adds the sub-shares of all refresh contributions to a share.
+//
+// Each sub-share must be destined for the share's ID. The returned share keeps
+// all metadata of the original share, and the original share must be
+// discarded afterwards.
+//
+// Returns an error if the value of the share or any sub-share is not in the
+// field, or if any sub-share is destined for a different ID, or was created
+// for a different threshold.
+func ApplyRefresh(share Share, subshares []Share, field gf.Field) (Share, error) {
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return share, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+	}
+
+	refreshed := share
+	refreshed.Value = new(big.Int).Set(share.Value)
+
+	for _, sub := range subshares {
+		if sub.Value == nil || !field.IsGroupElement(sub.Value) {
+			return share, fmt.Errorf("%w: value of sub-share for ID %d", ErrNotInField, sub.ID)
+		}
+
+		if sub.ID != share.ID {
+			return share, fmt.Errorf("Sub-share for ID %d cannot be applied to share with ID %d", sub.ID, share.ID)
+		}
+
+		if share.Threshold != 0 && sub.Threshold != share.Threshold {
+			return share, fmt.Errorf("Sub-share for threshold %d cannot be applied to share with threshold %d", sub.Threshold, share.Threshold)
+		}
+
+		refreshed.Value = field.Add(refreshed.Value, sub.Value)
+	}
+
+	return refreshed, nil
+}
+
+// VerifyRefreshContribution verifies that a sub-share is consistent with the
+// commitments of a refresh contribution, and that the contribution does not
+// change the shared secret, ie. that it commits to `δ(0) = 0`.
+func VerifyRefreshContribution(subshare Share, commitments FeldmanCommitments) bool {
+	if len(commitments.Values) == 0 || commitments.Values[0] == nil || commitments.Values[0].Cmp(big.NewInt(1)) != 0 {
+		return false
+	}
+
+	return VerifyShare(subshare, commitments)
+}
+
+// ApplyRefreshCommitments updates the commitments to the shared secret with
+// the commitments of all refresh contributions, such that refreshed shares
+// can be verified against them with `VerifyShare`.
+//
+// As each contribution commits to `δ(0) = 0`, the commitment to the secret
+// itself stays the same.
+//
+// Returns an error if the commitments are empty, or if any contribution does
+// not commit to `δ(0) = 0`, is of a different threshold or group, or holds
+// values not in the group.
+func ApplyRefreshCommitments(commitments FeldmanCommitments, contributions []FeldmanCommitments) (FeldmanCommitments, error) {
+	group := commitments.Group
+
+	if commitments.Threshold() == 0 {
+		return commitments, fmt.Errorf("%w: commitments are empty", ErrThreshold)
+	}
+
+	refreshed := FeldmanCommitments{Group: group, Values: make([]*big.Int, len(commitments.Values))}
+	copy(refreshed.Values, commitments.Values)
+
+	for _, contribution := range contributions {
+		if contribution.Group.P == nil || contribution.Group.G == nil || contribution.Group.P.Cmp(group.P) != 0 || contribution.Group.G.Cmp(group.G) != 0 {
+			return commitments, fmt.Errorf("Contribution is in a different group")
+		}
+
+		if contribution.Threshold() != commitments.Threshold() {
+			return commitments, fmt.Errorf("Contribution of threshold %d does not match threshold %d", contribution.Threshold(), commitments.Threshold())
+		}
+
+		for _, value := range contribution.Values {
+			if value == nil || !group.IsElement(value) {
+				return commitments, fmt.Errorf("Contribution holds value %d not in the group", value)
+			}
+		}
+
+		if contribution.Values[0].Cmp(big.NewInt(1)) != 0 {
+			return commitments, fmt.Errorf("Contribution would change the shared secret")
+		}
+
+		for i, value := range contribution.Values {
+			refreshed.Values[i] = group.Mul(refreshed.Values[i], value) // g^{a_i + δ_i}
+		}
+	}
+
+	return refreshed, nil
+}
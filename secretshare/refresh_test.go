@@ -0,0 +1,204 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func TestRefresh(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+	ids := []int{1, 2, 3, 4, 5}
+
+	shares, _, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	// Every shareholder contributes
+	contributions := make([][]Share, len(ids))
+	for i := range ids {
+		contributions[i], err = RefreshContribution(ids, 3, field)
+		if err != nil {
+			t.Fatalf("Error creating refresh contribution: %v", err)
+		}
+	}
+
+	refreshed := make([]Share, len(shares))
+	for i, share := range shares {
+		subshares := make([]Share, len(contributions))
+		for j, contribution := range contributions {
+			subshares[j] = contribution[i]
+		}
+
+		refreshed[i], err = ApplyRefresh(share, subshares, field)
+		if err != nil {
+			t.Fatalf("Error applying refresh: %v", err)
+		}
+
+		if refreshed[i].ID != share.ID || refreshed[i].Threshold != share.Threshold {
+			t.Errorf("Expected refreshed share to keep metadata of %v; got %v", share, refreshed[i])
+		}
+	}
+
+	reconstructed, err := TOutOfNRecover(refreshed, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestFeldmanRefresh(t *testing.T) {
+	group := testGroup()
+	secret := big.NewInt(42)
+	ids := []int{1, 2, 3, 4}
+
+	shares, commitments, err := FeldmanTOutOfN(secret, 2, 4, group)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	contributions := make([][]Share, len(ids))
+	contributionCommitments := make([]FeldmanCommitments, len(ids))
+	for i := range ids {
+		contributions[i], contributionCommitments[i], err = FeldmanRefreshContribution(ids, 2, group)
+		if err != nil {
+			t.Fatalf("Error creating refresh contribution: %v", err)
+		}
+	}
+
+	newCommitments, err := ApplyRefreshCommitments(commitments, contributionCommitments)
+	if err != nil {
+		t.Fatalf("Error applying refresh commitments: %v", err)
+	}
+	if newCommitments.Values[0].Cmp(commitments.Values[0]) != 0 {
+		t.Errorf("Expected commitment to secret to be unchanged")
+	}
+
+	refreshed := make([]Share, len(shares))
+	for i, share := range shares {
+		subshares := make([]Share, len(contributions))
+		for j, contribution := range contributions {
+			if !VerifyRefreshContribution(contribution[i], contributionCommitments[j]) {
+				t.Errorf("Expected sub-share %d of contribution %d to pass verification; did not", i, j)
+			}
+			subshares[j] = contribution[i]
+		}
+
+		refreshed[i], err = ApplyRefresh(share, subshares, group.ScalarField())
+		if err != nil {
+			t.Fatalf("Error applying refresh: %v", err)
+		}
+
+		if !VerifyShare(refreshed[i], newCommitments) {
+			t.Errorf("Expected refreshed share %d to pass verification; did not", refreshed[i].ID)
+		}
+	}
+
+	reconstructed, err := FeldmanRecover(refreshed, newCommitments)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestVerifyRefreshContribution(t *testing.T) {
+	group := testGroup()
+	ids := []int{1, 2, 3}
+
+	// A dealing of a non-zero constant would change the secret
+	shares, commitments, err := FeldmanTOutOfN(big.NewInt(5), 2, 3, group)
+	if err != nil {
+		t.Fatalf("Error creating verifiable t-out-of-n share: %v", err)
+	}
+
+	if VerifyRefreshContribution(shares[0], commitments) {
+		t.Errorf("Expected contribution with non-zero constant to fail verification; did not")
+	}
+
+	_, err = ApplyRefreshCommitments(commitments, []FeldmanCommitments{commitments})
+	if err == nil {
+		t.Errorf("Expected error when applying contribution with non-zero constant; got none")
+	}
+
+	_, contribution, err := FeldmanRefreshContribution(ids, 3, group)
+	if err != nil {
+		t.Fatalf("Error creating refresh contribution: %v", err)
+	}
+	_, err = ApplyRefreshCommitments(commitments, []FeldmanCommitments{contribution})
+	if err == nil {
+		t.Errorf("Expected error when applying contribution of different threshold; got none")
+	}
+
+	empty := FeldmanCommitments{Group: group}
+	_, err = ApplyRefreshCommitments(empty, []FeldmanCommitments{empty})
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold when applying empty contribution; got %v", err)
+	}
+
+	invalid := FeldmanCommitments{Group: group, Values: []*big.Int{nil, nil}}
+	_, err = ApplyRefreshCommitments(commitments, []FeldmanCommitments{invalid})
+	if err == nil {
+		t.Errorf("Expected error when applying contribution without values; got none")
+	}
+
+	if VerifyRefreshContribution(shares[0], invalid) {
+		t.Errorf("Expected contribution without values to fail verification; did not")
+	}
+}
+
+func TestRefreshInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	_, err := RefreshContribution([]int{1, 2, 3}, 1, field)
+	if err == nil {
+		t.Errorf("Expected error if t <= 1; got none")
+	}
+
+	_, err = RefreshContribution([]int{1, 2, 3}, 4, field)
+	if err == nil {
+		t.Errorf("Expected error if t > number of IDs; got none")
+	}
+
+	_, err = RefreshContribution([]int{1, 2, 2}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if IDs are not unique; got none")
+	}
+
+	_, err = RefreshContribution([]int{0, 1, 2}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if ID is zero; got none")
+	}
+
+	_, err = RefreshContribution([]int{1, 2, 53}, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if ID is not a group element; got none")
+	}
+
+	share := Share{ID: 1, Value: big.NewInt(10), Threshold: 2}
+	_, err = ApplyRefresh(share, []Share{{ID: 2, Value: big.NewInt(1), Threshold: 2}}, field)
+	if err == nil {
+		t.Errorf("Expected error if sub-share is for different ID; got none")
+	}
+
+	_, err = ApplyRefresh(share, []Share{{ID: 1, Value: big.NewInt(1), Threshold: 3}}, field)
+	if err == nil {
+		t.Errorf("Expected error if sub-share is for different threshold; got none")
+	}
+
+	_, err = ApplyRefresh(share, []Share{{ID: 1, Threshold: 2}}, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if sub-share has no value; got %v", err)
+	}
+
+	_, err = ApplyRefresh(Share{ID: 1, Threshold: 2}, []Share{{ID: 1, Value: big.NewInt(1), Threshold: 2}}, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if share has no value; got %v", err)
+	}
+}
@@ -0,0 +1,371 @@
+package secretshare
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// maxReplicatedPieces is the maximum number of pieces of a secret shared by
+// `ReplicatedSplit`, which grows with the binomial coefficient of n over
+// t - 1.
+const maxReplicatedPieces = 1 << 16
+
+// ReplicatedPiece is an additive piece of a secret shared with replicated
+// secret sharing, which belongs to a maximal unqualified set of parties.
+type ReplicatedPiece struct {
+	// Sorted IDs of the maximal unqualified set, none of which hold the
+	// piece
+	Set []int
+	// Value of the piece
+	Value *big.Int
+}
+
+// ReplicatedShare represents a single party's share of a secret shared with
+// replicated secret sharing, which consists of all pieces of the sets the
+// party does not belong to.
+type ReplicatedShare struct {
+	ID int
+	// Number of parties the secret is shared among
+	Parties int
+	// Number of shares required to recover the secret
+	Threshold int
+	// Pieces held by the party, ordered as their sets
+	Pieces []ReplicatedPiece
+	// Random identifier shared by all shares of the same secret, or nil if
+	// unknown
+	SecretID []byte
+	// Order of the field the secret was shared in, or nil if unknown
+	Order *big.Int
+}
+
+// ReplicatedSplit implements t-out-of-n replicated secret sharing, also
+// known as CNF secret sharing.
+//
+// The maximal unqualified sets are all sets of `t - 1` parties. The secret is
+// split additively into one random piece per such set, and each party holds
+// the pieces of all sets it does not belong to. Any t parties hold all
+// pieces, as no set of `t - 1` parties contains all of them, while any
+// `t - 1` parties miss the piece of their own set.
+//
+// The number of pieces is the binomial coefficient of n over `t - 1`, so
+// replicated sharing is only suitable for small numbers of parties. In return,
+// shares may be multiplied locally, which is the basis of fast
+// honest-majority MPC protocols.
+//
+// It is required that:
+// - 1 < t <= n < |field|
+// - the number of pieces does not exceed 2^16
+// - secret is an element of the field
+//
+// Returns a slice containing the shares, with IDs 1 to n.
+// An error is returned if any of the requirements are violated.
+func ReplicatedSplit(secret *big.Int, t int, n int, field gf.Field) ([]ReplicatedShare, error) {
+	var shares []ReplicatedShare
+
+	if t <= 1 || t > n {
+		return shares, fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, n)
+	}
+
+	if field.Order().Cmp(big.NewInt(int64(n))) <= 0 {
+		return shares, fmt.Errorf("%w: order %d for %d shares", ErrFieldTooSmall, field.Order(), n)
+	}
+
+	if secret == nil || !field.IsGroupElement(secret) {
+		return shares, fmt.Errorf("%w: secret", ErrNotInField)
+	}
+
+	if err := checkReplicatedPieces(t, n); err != nil {
+		return shares, err
+	}
+
+	secretID, err := newSecretID()
+	if err != nil {
+		return shares, err
+	}
+
+	// All pieces but the last are random, the last one ensures they sum up
+	// to the secret.
+	sets := combinations(n, t-1)
+	pieces := make([]ReplicatedPiece, len(sets))
+	rest := new(big.Int).Set(secret)
+	for i, set := range sets {
+		value := rest
+		if i < len(sets)-1 {
+			if value, err = field.Rand(); err != nil {
+				return shares, err
+			}
+			rest = field.Sub(rest, value)
+		}
+
+		pieces[i] = ReplicatedPiece{Set: set, Value: value}
+	}
+
+	shares = make([]ReplicatedShare, n)
+	for i := range shares {
+		id := i + 1
+		shares[i] = ReplicatedShare{
+			ID:        id,
+			Parties:   n,
+			Threshold: t,
+			SecretID:  secretID,
+			Order:     field.Order(),
+		}
+
+		for _, piece := range pieces {
+			if !containsID(piece.Set, id) {
+				shares[i].Pieces = append(shares[i].Pieces, piece)
+			}
+		}
+	}
+
+	return shares, nil
+}
+
+// ReplicatedRecover recovers a secret from at least t shares created by
+// `ReplicatedSplit`, by summing up the pieces of all sets.
+//
+// Each piece must belong to a set of `t - 1` sorted, unique IDs between 1 and
+// n which does not contain its holder, and pieces held by several parties are
+// checked for consistency.
+//
+// Returns an error if the shares have invalid parameters, are not unique, do
+// not belong to the same secret, hold invalid or inconsistent pieces, or
+// together miss the piece of any set.
+func ReplicatedRecover(shares []ReplicatedShare, field gf.Field) (*big.Int, error) {
+	var sum = &big.Int{}
+
+	if len(shares) == 0 {
+		return sum, fmt.Errorf("No shares supplied")
+	}
+
+	first := shares[0]
+	ids := make([]int, len(shares))
+	for i, share := range shares {
+		if share.Parties != first.Parties || share.Threshold != first.Threshold {
+			return sum, fmt.Errorf("Share with ID %d does not match parameters of other shares", share.ID)
+		}
+
+		if !bytes.Equal(share.SecretID, first.SecretID) {
+			return sum, fmt.Errorf("Share with ID %d belongs to secret %x; expected %x", share.ID, share.SecretID, first.SecretID)
+		}
+
+		if share.Order != nil && share.Order.Cmp(field.Order()) != 0 {
+			return sum, fmt.Errorf("Share with ID %d is in field of order %d; expected %d", share.ID, share.Order, field.Order())
+		}
+
+		ids[i] = share.ID
+	}
+
+	if err := checkReplicatedParams(first, field); err != nil {
+		return sum, err
+	}
+
+	if err := checkIDs(ids, field); err != nil {
+		return sum, err
+	}
+
+	if len(shares) < first.Threshold {
+		return sum, fmt.Errorf("%w: at least %d shares required; got %d", ErrThreshold, first.Threshold, len(shares))
+	}
+
+	pieces := make(map[string]*big.Int)
+	for _, share := range shares {
+		if err := checkReplicatedShare(share, field); err != nil {
+			return sum, err
+		}
+
+		for _, piece := range share.Pieces {
+			key := setKey(piece.Set)
+			if value, ok := pieces[key]; ok {
+				if value.Cmp(piece.Value) != 0 {
+					return sum, fmt.Errorf("Shares disagree on piece of set %v", piece.Set)
+				}
+				continue
+			}
+			pieces[key] = piece.Value
+		}
+	}
+
+	for _, set := range combinations(first.Parties, first.Threshold-1) {
+		value, ok := pieces[setKey(set)]
+		if !ok {
+			return sum, fmt.Errorf("%w: piece of set %v is missing", ErrThreshold, set)
+		}
+
+		sum = field.Add(sum, value)
+	}
+
+	return sum, nil
+}
+
+// ReplicatedToShamir converts a replicated share into a t-out-of-n Shamir
+// share of the same secret, without any interaction.
+//
+// For each set T, let `f_T` be the polynomial of degree `t - 1` with
+// `f_T(0) = 1` and `f_T(j) = 0` for all j in T:
+// `f_T(x) = Product for j in T [ (j - x) / j ]`
+// The polynomial `F(x) = Sum for T [ r_T * f_T(x) ]` then shares the secret
+// `F(0) = Sum for T [ r_T ]`. As `f_T(i) = 0` for sets containing party i,
+// its share `F(i)` only depends on the pieces it holds.
+//
+// Returns the Shamir share of the party.
+// An error is returned if the share has invalid parameters, holds invalid
+// pieces, or misses the piece of any set it does not belong to.
+func ReplicatedToShamir(share ReplicatedShare, field gf.Field) (Share, error) {
+	var out Share
+
+	if err := checkReplicatedParams(share, field); err != nil {
+		return out, err
+	}
+
+	if err := checkIDs([]int{share.ID}, field); err != nil {
+		return out, err
+	}
+
+	if err := checkReplicatedShare(share, field); err != nil {
+		return out, err
+	}
+
+	// Pieces are unique and valid, so the share is complete if it holds as
+	// many as there are sets without its ID.
+	expected := new(big.Int).Binomial(int64(share.Parties-1), int64(share.Threshold-1))
+	if expected.Cmp(big.NewInt(int64(len(share.Pieces)))) != 0 {
+		return out, fmt.Errorf("%w: share with ID %d holds %d pieces; expected %d", ErrThreshold, share.ID, len(share.Pieces), expected)
+	}
+
+	x := big.NewInt(int64(share.ID))
+	var sum = &big.Int{}
+	for _, piece := range share.Pieces {
+		f := big.NewInt(1)
+		for _, id := range piece.Set {
+			j := big.NewInt(int64(id))
+			f = field.Mul(f, field.Div(field.Sub(j, x), j)) // (j - x) / j
+		}
+
+		sum = field.Add(sum, field.Mul(piece.Value, f)) // r_T * f_T(i)
+	}
+
+	out = Share{
+		ID:        share.ID,
+		Value:     sum,
+		Threshold: share.Threshold,
+		SecretID:  share.SecretID,
+		Order:     field.Order(),
+	}
+
+	return out, nil
+}
+
+// checkReplicatedPieces checks that the number of pieces of t-out-of-n
+// replicated sharing does not exceed `maxReplicatedPieces`.
+func checkReplicatedPieces(t int, n int) error {
+	count := new(big.Int).Binomial(int64(n), int64(t-1))
+	if count.Cmp(big.NewInt(maxReplicatedPieces)) > 0 {
+		return fmt.Errorf("Replicated sharing among %d parties with threshold %d requires %d pieces; at most %d supported", n, t, count, maxReplicatedPieces)
+	}
+
+	return nil
+}
+
+// checkReplicatedParams checks the parameters of a replicated share, which
+// must satisfy the requirements of `ReplicatedSplit`.
+func checkReplicatedParams(share ReplicatedShare, field gf.Field) error {
+	t, n := share.Threshold, share.Parties
+
+	if t <= 1 || t > n {
+		return fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, n)
+	}
+
+	if field.Order().Cmp(big.NewInt(int64(n))) <= 0 {
+		return fmt.Errorf("%w: order %d for %d shares", ErrFieldTooSmall, field.Order(), n)
+	}
+
+	return checkReplicatedPieces(t, n)
+}
+
+// checkReplicatedShare checks that a replicated share with valid parameters
+// has an ID between 1 and n, and that each of its pieces is in the field and
+// belongs to a unique set of `t - 1` sorted, unique IDs between 1 and n, which
+// does not contain the share's ID.
+func checkReplicatedShare(share ReplicatedShare, field gf.Field) error {
+	if share.ID < 1 || share.ID > share.Parties {
+		return fmt.Errorf("Share ID %d is not between 1 and %d", share.ID, share.Parties)
+	}
+
+	seen := make(map[string]bool)
+	for _, piece := range share.Pieces {
+		if piece.Value == nil || !field.IsGroupElement(piece.Value) {
+			return fmt.Errorf("%w: piece of set %v of share with ID %d", ErrNotInField, piece.Set, share.ID)
+		}
+
+		if len(piece.Set) != share.Threshold-1 {
+			return fmt.Errorf("Share with ID %d holds piece of set %v; expected %d IDs", share.ID, piece.Set, share.Threshold-1)
+		}
+
+		for i, id := range piece.Set {
+			if id < 1 || id > share.Parties || (i > 0 && id <= piece.Set[i-1]) {
+				return fmt.Errorf("Share with ID %d holds piece of set %v; expected sorted, unique IDs between 1 and %d", share.ID, piece.Set, share.Parties)
+			}
+		}
+
+		if containsID(piece.Set, share.ID) {
+			return fmt.Errorf("Share with ID %d holds piece of its own set %v", share.ID, piece.Set)
+		}
+
+		key := setKey(piece.Set)
+		if seen[key] {
+			return fmt.Errorf("Share with ID %d holds piece of set %v twice", share.ID, piece.Set)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// combinations returns all sets of k IDs out of the IDs 1 to n, each sorted,
+// in lexicographic order.
+func combinations(n int, k int) [][]int {
+	var sets [][]int
+
+	set := make([]int, k)
+	var choose func(start int, i int)
+	choose = func(start int, i int) {
+		if i == k {
+			sets = append(sets, append([]int(nil), set...))
+			return
+		}
+
+		for id := start; id <= n-(k-i)+1; id++ {
+			set[i] = id
+			choose(id+1, i+1)
+		}
+	}
+	choose(1, 0)
+
+	return sets
+}
+
+// containsID checks whether a sorted set contains an ID.
+func containsID(set []int, id int) bool {
+	i := sort.SearchInts(set, id)
+
+	return i < len(set) && set[i] == id
+}
+
+// setKey derives the map key of a set of IDs.
+func setKey(set []int) string {
+	var b strings.Builder
+
+	for i, id := range set {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%d", id)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,276 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestReplicatedSplit(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+
+	shares, err := ReplicatedSplit(secret, 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	// The maximal unqualified sets are {1}, {2} and {3}, and each party
+	// holds the pieces of the two sets it does not belong to.
+	for _, share := range shares {
+		if len(share.Pieces) != 2 {
+			t.Fatalf("Expected share %d to hold 2 pieces; got %d", share.ID, len(share.Pieces))
+		}
+
+		for _, piece := range share.Pieces {
+			if containsID(piece.Set, share.ID) {
+				t.Errorf("Expected share %d not to hold piece of set %v", share.ID, piece.Set)
+			}
+		}
+	}
+
+	for _, pair := range [][]int{{0, 1}, {1, 2}, {2, 0}} {
+		recovered, err := ReplicatedRecover([]ReplicatedShare{shares[pair[0]], shares[pair[1]]}, field)
+		if err != nil {
+			t.Fatalf("Error recovering secret: %v", err)
+		}
+		if recovered.Cmp(secret) != 0 {
+			t.Errorf("Expected to recover %d from shares %v; got %d", secret, pair, recovered)
+		}
+	}
+
+	_, err = ReplicatedRecover(shares[:1], field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if fewer than t shares given; got %v", err)
+	}
+}
+
+func TestReplicatedFourParties(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(777)
+
+	shares, err := ReplicatedSplit(secret, 3, 4, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	// 6 sets of two parties, of which each party belongs to 3
+	for _, share := range shares {
+		if len(share.Pieces) != 3 {
+			t.Errorf("Expected share %d to hold 3 pieces; got %d", share.ID, len(share.Pieces))
+		}
+	}
+
+	recovered, err := ReplicatedRecover([]ReplicatedShare{shares[3], shares[0], shares[2]}, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if recovered.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, recovered)
+	}
+
+	// Only share 4 holds the piece of set {1, 2} among shares 1, 2 and 4
+	partial := shares[3]
+	partial.Pieces = partial.Pieces[1:]
+	_, err = ReplicatedRecover([]ReplicatedShare{shares[0], shares[1], partial}, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if piece is missing; got %v", err)
+	}
+}
+
+func TestReplicatedRecoverInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, err := ReplicatedSplit(big.NewInt(42), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	_, err = ReplicatedRecover([]ReplicatedShare{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no shares given; got none")
+	}
+
+	_, err = ReplicatedRecover([]ReplicatedShare{shares[0], shares[0]}, field)
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if duplicate shares given; got %v", err)
+	}
+
+	// Shares 1 and 2 both hold the piece of set {3}
+	tampered := shares[1]
+	tampered.Pieces = make([]ReplicatedPiece, len(shares[1].Pieces))
+	copy(tampered.Pieces, shares[1].Pieces)
+	for i, piece := range tampered.Pieces {
+		if reflect.DeepEqual(piece.Set, []int{3}) {
+			tampered.Pieces[i].Value = field.Add(piece.Value, big.NewInt(1))
+		}
+	}
+	_, err = ReplicatedRecover([]ReplicatedShare{shares[0], tampered}, field)
+	if err == nil {
+		t.Errorf("Expected error if shares hold inconsistent pieces; got none")
+	}
+
+	other, err := ReplicatedSplit(big.NewInt(42), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+	_, err = ReplicatedRecover([]ReplicatedShare{shares[0], other[1]}, field)
+	if err == nil {
+		t.Errorf("Expected error if shares belong to different secrets; got none")
+	}
+}
+
+func TestReplicatedRecoverInvalidParams(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, err := ReplicatedSplit(big.NewInt(42), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	for _, params := range [][2]int{{0, 3}, {1, 3}, {4, 3}, {2, -1}} {
+		pair := []ReplicatedShare{shares[0], shares[1]}
+		for i := range pair {
+			pair[i].Threshold, pair[i].Parties = params[0], params[1]
+		}
+
+		_, err = ReplicatedRecover(pair, field)
+		if !errors.Is(err, ErrThreshold) {
+			t.Errorf("Expected ErrThreshold for t = %d, n = %d; got %v", params[0], params[1], err)
+		}
+	}
+
+	// Too many sets to enumerate
+	pair := []ReplicatedShare{shares[0], shares[1]}
+	for i := range pair {
+		pair[i].Threshold, pair[i].Parties = 500, 1000
+	}
+	_, err = ReplicatedRecover(pair, field)
+	if err == nil {
+		t.Errorf("Expected error if too many pieces are required; got none")
+	}
+}
+
+func TestReplicatedRecoverInvalidPieces(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, err := ReplicatedSplit(big.NewInt(42), 3, 4, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	// Share 1 holds the pieces of sets {2, 3}, {2, 4} and {3, 4}, of which
+	// the last one is replaced by a forged one.
+	sets := [][]int{
+		{4, 3},    // unsorted
+		{3, 3},    // duplicate IDs
+		{3},       // wrong size
+		{2, 3, 4}, // wrong size
+		{1, 3},    // contains the holder
+		{3, 5},    // ID out of range
+		{2, 3},    // held twice
+	}
+	for _, set := range sets {
+		forged := shares[0]
+		forged.Pieces = append(append([]ReplicatedPiece(nil), shares[0].Pieces[:2]...), ReplicatedPiece{Set: set, Value: big.NewInt(1)})
+
+		_, err = ReplicatedRecover([]ReplicatedShare{forged, shares[1], shares[2]}, field)
+		if err == nil {
+			t.Errorf("Expected error if share holds piece of set %v; got none", set)
+		}
+
+		_, err = ReplicatedToShamir(forged, field)
+		if err == nil {
+			t.Errorf("Expected error converting share with piece of set %v; got none", set)
+		}
+	}
+}
+
+func TestReplicatedSplitInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	_, err := ReplicatedSplit(big.NewInt(42), 1, 3, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t <= 1; got %v", err)
+	}
+
+	_, err = ReplicatedSplit(big.NewInt(42), 4, 3, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t > n; got %v", err)
+	}
+
+	_, err = ReplicatedSplit(big.NewInt(42), 2, 53, field)
+	if !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected ErrFieldTooSmall if n >= p; got %v", err)
+	}
+
+	_, err = ReplicatedSplit(big.NewInt(53), 2, 3, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if secret not in field; got %v", err)
+	}
+
+	_, err = ReplicatedSplit(big.NewInt(42), 20, 40, gf.GF{P: big.NewInt(1019)})
+	if err == nil {
+		t.Errorf("Expected error if too many pieces are required; got none")
+	}
+}
+
+func TestReplicatedToShamir(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(555)
+
+	for _, params := range [][2]int{{2, 3}, {3, 4}, {2, 4}} {
+		threshold, n := params[0], params[1]
+
+		replicated, err := ReplicatedSplit(secret, threshold, n, field)
+		if err != nil {
+			t.Fatalf("Error creating replicated shares: %v", err)
+		}
+
+		shamir := make([]Share, n)
+		for i, share := range replicated {
+			shamir[i], err = ReplicatedToShamir(share, field)
+			if err != nil {
+				t.Fatalf("Error converting share %d: %v", share.ID, err)
+			}
+		}
+
+		// All shares lie on the same polynomial of degree t - 1
+		recovered, err := TOutOfNRecover(shamir, field)
+		if err != nil {
+			t.Fatalf("Error recovering secret from %d-out-of-%d shares: %v", threshold, n, err)
+		}
+		if recovered.Cmp(secret) != 0 {
+			t.Errorf("Expected to recover %d from %d-out-of-%d shares; got %d", secret, threshold, n, recovered)
+		}
+	}
+}
+
+func TestReplicatedToShamirMissingPiece(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, err := ReplicatedSplit(big.NewInt(555), 2, 3, field)
+	if err != nil {
+		t.Fatalf("Error creating replicated shares: %v", err)
+	}
+
+	partial := shares[0]
+	partial.Pieces = partial.Pieces[1:]
+	_, err = ReplicatedToShamir(partial, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if share misses a piece; got %v", err)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	expected := [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+	if actual := combinations(4, 2); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected combinations %v; got %v", expected, actual)
+	}
+
+	if actual := combinations(3, 0); len(actual) != 1 || len(actual[0]) != 0 {
+		t.Errorf("Expected single empty combination; got %v", actual)
+	}
+}
@@ -0,0 +1,148 @@
+package secretshare
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// SubShare represents an old shareholder's share of its own share, destined
+// for a new shareholder during resharing.
+type SubShare struct {
+	// ID of the old shareholder which created the sub-share
+	From int
+	// Threshold of the old shares, or 0 if unknown
+	OldThreshold int
+	// Sub-share for the new shareholder, carrying the new shareholder's
+	// ID and the new threshold
+	Share
+}
+
+// Reshare creates an old shareholder's contribution to moving a shared secret
+// to a new threshold and a new set of shareholders, without reconstructing
+// the secret.
+//
+// The old shareholder shares its own share value `s_i` with a random
+// polynomial `g_i` of degree `newT - 1`, with `g_i(0) = s_i`, and returns the
+// sub-share `g_i(id)` for each new shareholder. At least `t` old shareholders
+// must contribute, and each new shareholder combines the sub-shares it
+// received with `CombineReshares`.
+//
+// It is required that:
+// - 1 < newT <= len(newIDs)
+// - newIDs are unique, non-zero elements of the field
+//
+// Returns a slice containing one sub-share for each new ID.
+// An error is returned if any of the requirements are violated.
+func Reshare(share Share, newT int, newIDs []int, field gf.Field) ([]SubShare, error) {
+	subshares := make([]SubShare, len(newIDs))
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return subshares, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+	}
+
+	shares, _, err := dealShares(share.Value, newT, newIDs, field)
+	if err != nil {
+		return subshares, err
+	}
+
+	for i, sub := range shares {
+		sub.SecretID = share.SecretID
+		sub.Order = field.Order()
+
+		subshares[i] = SubShare{From: share.ID, OldThreshold: share.Threshold, Share: sub}
+	}
+
+	return subshares, nil
+}
+
+// CombineReshares combines the sub-shares a new shareholder received from the
+// old shareholders into its new share.
+//
+// Recall that the secret is `s = Sum for j [ l_j(0) * s_j ]`, where `l_j` are
+// the Lagrange base polynomials of the old shareholders' IDs. As resharing is
+// linear, the new share is the same combination of the sub-shares:
+// `s'_k = Sum for j [ l_j(0) * g_j(k) ]`
+//
+// The sub-shares must stem from *exactly* `t` *unique* old shareholders, and
+// all new shareholders must combine sub-shares from the same old shareholders.
+//
+// Returns an error if the sub-shares are destined for different shareholders,
+// do not stem from unique old shareholders, or their number does not match
+// the old threshold.
+func CombineReshares(subshares []SubShare, field gf.Field) (Share, error) {
+	var share Share
+
+	if err := checkSubShares(subshares, field); err != nil {
+		return share, err
+	}
+
+	xs := make([]*big.Int, len(subshares))
+	for i, sub := range subshares {
+		xs[i] = big.NewInt(int64(sub.From))
+	}
+
+	var sum = &big.Int{}
+	for j, sub := range subshares {
+		basePoly := gf.BasePolynomial(j, xs, field) // l_j(0)
+		term := field.Mul(sub.Value, basePoly)      // g_j(k) * l_j(0)
+		sum = field.Add(sum, term)
+	}
+
+	first := subshares[0]
+	share = Share{
+		ID:        first.ID,
+		Value:     sum,
+		Threshold: first.Threshold,
+		SecretID:  first.SecretID,
+		Order:     field.Order(),
+	}
+
+	return share, nil
+}
+
+// checkSubShares checks that sub-shares are destined for the same shareholder
+// with the same threshold, stem from unique old shareholders, and belong to the
+// same secret.
+//
+// Returns an error if any of the checks fails, or if the number of sub-shares
+// does not match the old threshold.
+func checkSubShares(subshares []SubShare, field gf.Field) error {
+	if len(subshares) == 0 {
+		return fmt.Errorf("No sub-shares supplied")
+	}
+
+	first := subshares[0]
+	if first.OldThreshold != 0 && len(subshares) != first.OldThreshold {
+		return fmt.Errorf("%w: exactly %d sub-shares required; got %d", ErrThreshold, first.OldThreshold, len(subshares))
+	}
+
+	from := make([]int, len(subshares))
+	for i, sub := range subshares {
+		from[i] = sub.From
+	}
+	if err := checkIDs(from, field); err != nil {
+		return err
+	}
+
+	for _, sub := range subshares {
+		if sub.ID != first.ID {
+			return fmt.Errorf("Sub-share for ID %d cannot be combined with sub-share for ID %d", sub.ID, first.ID)
+		}
+
+		if sub.Threshold != first.Threshold || sub.OldThreshold != first.OldThreshold {
+			return fmt.Errorf("Sub-share from ID %d does not match threshold of other sub-shares", sub.From)
+		}
+
+		if !bytes.Equal(sub.SecretID, first.SecretID) {
+			return fmt.Errorf("Sub-share from ID %d belongs to a different secret", sub.From)
+		}
+
+		if sub.Value == nil || !field.IsGroupElement(sub.Value) {
+			return fmt.Errorf("%w: value of sub-share from ID %d", ErrNotInField, sub.From)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package secretshare
+
+import (
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"testing"
+)
+
+func TestReshare(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+
+	// Move from 3-out-of-5 to 4-out-of-7
+	shares, _, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	newIDs := []int{1, 2, 3, 4, 5, 6, 7}
+	oldHolders := []Share{shares[4], shares[0], shares[2]}
+
+	received := make([][]SubShare, len(newIDs))
+	for _, share := range oldHolders {
+		subshares, err := Reshare(share, 4, newIDs, field)
+		if err != nil {
+			t.Fatalf("Error resharing: %v", err)
+		}
+
+		for k, sub := range subshares {
+			received[k] = append(received[k], sub)
+		}
+	}
+
+	newShares := make([]Share, len(newIDs))
+	for k := range newIDs {
+		newShares[k], err = CombineReshares(received[k], field)
+		if err != nil {
+			t.Fatalf("Error combining sub-shares: %v", err)
+		}
+
+		if newShares[k].ID != newIDs[k] || newShares[k].Threshold != 4 {
+			t.Errorf("Expected new share %d with threshold 4; got %d with threshold %d", newIDs[k], newShares[k].ID, newShares[k].Threshold)
+		}
+	}
+
+	// All new shares lie on the same polynomial of degree 3
+	reconstructed, err := TOutOfNRecover(newShares, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+
+	reconstructed, err = TOutOfNRecover(newShares[3:], field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestReshareInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+	share := Share{ID: 1, Value: big.NewInt(10), Threshold: 2}
+
+	_, err := Reshare(share, 1, []int{1, 2, 3}, field)
+	if err == nil {
+		t.Errorf("Expected error if new t <= 1; got none")
+	}
+
+	_, err = Reshare(share, 2, []int{1, 1, 3}, field)
+	if err == nil {
+		t.Errorf("Expected error if new IDs are not unique; got none")
+	}
+
+	_, err = Reshare(Share{ID: 1, Value: big.NewInt(53)}, 2, []int{1, 2, 3}, field)
+	if err == nil {
+		t.Errorf("Expected error if share is not a group element; got none")
+	}
+}
+
+func TestCombineResharesInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+
+	_, err := CombineReshares([]SubShare{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no sub-shares given; got none")
+	}
+
+	sub := func(from int, to int) SubShare {
+		return SubShare{From: from, OldThreshold: 2, Share: Share{ID: to, Value: big.NewInt(1), Threshold: 3}}
+	}
+
+	_, err = CombineReshares([]SubShare{sub(1, 1)}, field)
+	if err == nil {
+		t.Errorf("Expected error if fewer than old t sub-shares given; got none")
+	}
+
+	_, err = CombineReshares([]SubShare{sub(1, 1), sub(2, 2)}, field)
+	if err == nil {
+		t.Errorf("Expected error if sub-shares for different IDs given; got none")
+	}
+
+	_, err = CombineReshares([]SubShare{sub(1, 1), sub(1, 1)}, field)
+	if err == nil {
+		t.Errorf("Expected error if duplicate sub-shares given; got none")
+	}
+}
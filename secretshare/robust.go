@@ -0,0 +1,202 @@
+package secretshare
+
+import (
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+)
+
+// TOutOfNRecoverRobust recovers a secret from any number `m >= t` of shares, of
+// which up to `(m - t) / 2` may be corrupted or malicious.
+//
+// The shares are decoded as a Reed-Solomon codeword using the Berlekamp-Welch
+// algorithm: We look for a monic error locator polynomial `E` of degree
+// `e = (m - t) / 2` and a polynomial `Q` of degree at most `e + t - 1` such
+// that `Q(x_i) = y_i * E(x_i)` for all shares. The sharing polynomial is then
+// `p = Q / E`, and `E` vanishes at the IDs of all corrupted shares.
+//
+// Returns the secret and the IDs of all shares which do not lie on the
+// sharing polynomial.
+// An error is returned if shares are not unique, if fewer than `t` shares are
+// supplied, if their metadata shows that they do not belong to the same
+// secret with threshold `t`, or if more than `(m - t) / 2` shares are
+// corrupted.
+func TOutOfNRecoverRobust(shares []Share, t int, field gf.Field) (*big.Int, []int, error) {
+	var secret = &big.Int{}
+	var corrupted []int
+
+	if t < 1 {
+		return secret, corrupted, fmt.Errorf("%w: t = %d", ErrThreshold, t)
+	}
+
+	m := len(shares)
+	if m < t {
+		return secret, corrupted, fmt.Errorf("%w: at least %d shares required; got %d", ErrThreshold, t, m)
+	}
+
+	if err := checkThreshold(shares, t); err != nil {
+		return secret, corrupted, err
+	}
+
+	if err := checkSameSecret(shares, field); err != nil {
+		return secret, corrupted, err
+	}
+
+	if err := checkIDs(shareIDs(shares), field); err != nil {
+		return secret, corrupted, err
+	}
+
+	xs := make([]*big.Int, m)
+	for i, share := range shares {
+		if share.Value == nil || !field.IsGroupElement(share.Value) {
+			return secret, corrupted, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
+		}
+		xs[i] = big.NewInt(int64(share.ID))
+	}
+
+	// Maximum number of errors we are able to correct
+	e := (m - t) / 2
+
+	// Unknowns are the coefficients q_0 ... q_{e+t-1} of Q, followed by
+	// the coefficients E_0 ... E_{e-1} of E. E_e = 1, as E is monic.
+	//   Sum q_k x_i^k - y_i * Sum E_k x_i^k = y_i * x_i^e
+	unknowns := 2*e + t
+	system := make([][]*big.Int, m)
+	for i, share := range shares {
+		row := make([]*big.Int, unknowns+1)
+
+		pow := big.NewInt(1) // x_i^k
+		for k := 0; k < e+t; k++ {
+			row[k] = pow
+			if k < e {
+				row[e+t+k] = field.Sub(big.NewInt(0), field.Mul(share.Value, pow))
+			}
+			if k == e {
+				row[unknowns] = field.Mul(share.Value, pow)
+			}
+
+			pow = field.Mul(pow, xs[i])
+		}
+
+		system[i] = row
+	}
+
+	solution, err := solveLinearSystem(system, field)
+	if err != nil {
+		return secret, corrupted, fmt.Errorf("Too many corrupted shares to recover secret")
+	}
+
+	q := gf.Polynomial{Field: field, Coefficients: solution[:e+t]}
+	locator := gf.Polynomial{
+		Field:        field,
+		Coefficients: append(append([]*big.Int{}, solution[e+t:]...), big.NewInt(1)),
+	}
+
+	p, rem, err := q.DivMod(locator)
+	if err != nil {
+		return secret, corrupted, err
+	}
+	if !rem.IsZero() {
+		return secret, corrupted, fmt.Errorf("Too many corrupted shares to recover secret")
+	}
+
+	for i, share := range shares {
+		y, err := p.Evaluate(xs[i])
+		if err != nil {
+			return secret, corrupted, err
+		}
+
+		if y.Cmp(share.Value) != 0 {
+			corrupted = append(corrupted, share.ID)
+		}
+	}
+
+	if len(corrupted) > e {
+		return secret, corrupted, fmt.Errorf("Too many corrupted shares to recover secret")
+	}
+
+	secret.Set(p.Coefficients[0])
+	return secret, corrupted, nil
+}
+
+// solveLinearSystem solves a system of linear equations over the finite field
+// `field` using Gaussian elimination.
+//
+// Each row of the system contains the coefficients of one equation, followed
+// by its right-hand side. If the system is underdetermined, free variables are
+// set to zero.
+//
+// Returns an error if the system has no solution.
+func solveLinearSystem(system [][]*big.Int, field gf.Field) ([]*big.Int, error) {
+	var solution []*big.Int
+
+	if len(system) == 0 {
+		return solution, nil
+	}
+
+	rows := len(system)
+	cols := len(system[0]) - 1
+
+	// Work on a copy, reduced into the field
+	mat := make([][]*big.Int, rows)
+	for i, row := range system {
+		mat[i] = make([]*big.Int, cols+1)
+		for j, v := range row {
+			mat[i][j] = field.Add(v, big.NewInt(0))
+		}
+	}
+
+	// Reduce to reduced row echelon form
+	pivots := make([]int, 0, cols)
+	r := 0
+	for c := 0; c < cols && r < rows; c++ {
+		pivot := -1
+		for i := r; i < rows; i++ {
+			if mat[i][c].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			continue
+		}
+		mat[r], mat[pivot] = mat[pivot], mat[r]
+
+		inv := field.MultInverse(mat[r][c])
+		for j := c; j <= cols; j++ {
+			mat[r][j] = field.Mul(mat[r][j], inv)
+		}
+
+		for i := 0; i < rows; i++ {
+			if i == r || mat[i][c].Sign() == 0 {
+				continue
+			}
+
+			factor := mat[i][c]
+			for j := c; j <= cols; j++ {
+				mat[i][j] = field.Sub(mat[i][j], field.Mul(factor, mat[r][j]))
+			}
+		}
+
+		pivots = append(pivots, c)
+		r++
+	}
+
+	// Any remaining row is of the form 0 = b, which is only satisfiable
+	// for b = 0
+	for i := r; i < rows; i++ {
+		if mat[i][cols].Sign() != 0 {
+			return solution, fmt.Errorf("System of equations has no solution")
+		}
+	}
+
+	solution = make([]*big.Int, cols)
+	for j := range solution {
+		solution[j] = big.NewInt(0)
+	}
+	for i, c := range pivots {
+		solution[c] = mat[i][cols]
+	}
+
+	return solution, nil
+}
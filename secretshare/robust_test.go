@@ -0,0 +1,136 @@
+package secretshare
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestTOutOfNRecoverRobust(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+
+	shares, _, err := TOutOfN(secret, 3, 7, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	// No corrupted shares
+	actual, corrupted, err := TOutOfNRecoverRobust(shares, 3, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if actual.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, actual)
+	}
+	if len(corrupted) != 0 {
+		t.Errorf("Expected no corrupted shares; got %v", corrupted)
+	}
+
+	// Two corrupted shares out of seven may be corrected
+	tampered := make([]Share, len(shares))
+	copy(tampered, shares)
+	tampered[1].Value = field.Add(shares[1].Value, big.NewInt(1))
+	tampered[5].Value = field.Add(shares[5].Value, big.NewInt(500))
+
+	actual, corrupted, err = TOutOfNRecoverRobust(tampered, 3, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if actual.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, actual)
+	}
+	if !reflect.DeepEqual(corrupted, []int{2, 6}) {
+		t.Errorf("Expected shares [2 6] to be corrupted; got %v", corrupted)
+	}
+
+	// Exactly t shares, nothing to correct
+	actual, corrupted, err = TOutOfNRecoverRobust(shares[2:5], 3, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if actual.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, actual)
+	}
+	if len(corrupted) != 0 {
+		t.Errorf("Expected no corrupted shares; got %v", corrupted)
+	}
+}
+
+func TestTOutOfNRecoverRobustTooManyErrors(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+
+	shares, _, err := TOutOfN(big.NewInt(42), 3, 4, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	// With t + 1 shares a single error can be detected, but not corrected
+	shares[0].Value = field.Add(shares[0].Value, big.NewInt(1))
+	_, _, err = TOutOfNRecoverRobust(shares, 3, field)
+	if err == nil {
+		t.Errorf("Expected error if too many shares are corrupted; got none")
+	}
+}
+
+func TestTOutOfNRecoverRobustInvalidInputs(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+	shares := []Share{
+		{ID: 1, Value: big.NewInt(10)},
+		{ID: 2, Value: big.NewInt(11)},
+		{ID: 1, Value: big.NewInt(12)},
+	}
+
+	_, _, err := TOutOfNRecoverRobust(shares, 2, field)
+	if err == nil {
+		t.Errorf("Expected error if duplicate shares given; got none")
+	}
+
+	_, _, err = TOutOfNRecoverRobust(shares[:2], 3, field)
+	if err == nil {
+		t.Errorf("Expected error if fewer than t shares given; got none")
+	}
+
+	_, _, err = TOutOfNRecoverRobust(shares[:2], 0, field)
+	if err == nil {
+		t.Errorf("Expected error if t < 1; got none")
+	}
+
+	created, _, err := TOutOfN(big.NewInt(42), 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+	_, _, err = TOutOfNRecoverRobust(created, 2, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t does not match threshold of shares; got %v", err)
+	}
+}
+
+func TestSolveLinearSystem(t *testing.T) {
+	field := gf.GF{P: big.NewInt(17)}
+
+	// x + 2y = 5, 3x + 4y = 6 => x = 13, y = 13
+	system := [][]*big.Int{
+		{big.NewInt(1), big.NewInt(2), big.NewInt(5)},
+		{big.NewInt(3), big.NewInt(4), big.NewInt(6)},
+	}
+	solution, err := solveLinearSystem(system, field)
+	if err != nil {
+		t.Fatalf("Error solving system: %v", err)
+	}
+	if solution[0].Cmp(big.NewInt(13)) != 0 || solution[1].Cmp(big.NewInt(13)) != 0 {
+		t.Errorf("Expected solution [13 13]; got %v", solution)
+	}
+
+	// x + y = 1, 2x + 2y = 3 has no solution
+	system = [][]*big.Int{
+		{big.NewInt(1), big.NewInt(1), big.NewInt(1)},
+		{big.NewInt(2), big.NewInt(2), big.NewInt(3)},
+	}
+	_, err = solveLinearSystem(system, field)
+	if err == nil {
+		t.Errorf("Expected error for inconsistent system; got none")
+	}
+}
@@ -1,6 +1,8 @@
 package secretshare
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
 	"github.com/lavode/secret-sharing/gf"
 	"math/big"
@@ -10,35 +12,59 @@ import (
 type Share struct {
 	ID    int
 	Value *big.Int
+	// Number of shares required to recover the secret, or 0 if unknown
+	Threshold int
+	// Random identifier shared by all shares of the same secret, or nil if
+	// unknown
+	SecretID []byte
+	// Order of the field the secret was shared in, or nil if unknown
+	Order *big.Int
+}
+
+// SecretIDLength is the length in bytes of the identifiers generated by
+// `TOutOfN`.
+const SecretIDLength = 16
+
+// newSecretID returns a random identifier for a shared secret.
+func newSecretID() ([]byte, error) {
+	id := make([]byte, SecretIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return id, fmt.Errorf("Error generating secret identifier: %v", err)
+	}
+
+	return id, nil
 }
 
 // TOutOfN implements t-out-of-n secret sharing using polynomials over a finite
-// field GF(p).
+// field, such as a prime field GF(p) or GF(2^8).
 //
 // It is required that:
 // - 1 < t <= n
-// - secret, t, n are elements of GF(p)
+// - n < |field|, such that each share has a unique, non-zero ID
+// - secret is an element of the field
 //
 // Returns a slice containing the shares and the polynomial used to calculate
 // the shares.
-// An error is returned if any of the requirements are violated.
-func TOutOfN(secret *big.Int, t int, n int, field gf.GF) ([]Share, gf.Polynomial, error) {
+// An error is returned if any of the requirements are violated, wrapping
+// `ErrThreshold`, `ErrFieldTooSmall` or `ErrNotInField` respectively.
+func TOutOfN(secret *big.Int, t int, n int, field gf.Field) ([]Share, gf.Polynomial, error) {
 	var pol gf.Polynomial
-	shares := make([]Share, n)
+	var shares []Share
 
-	if t <= 1 || t > n || !field.IsGroupElement(big.NewInt(int64(t))) {
-		return shares, pol, fmt.Errorf("Invalid value for t")
+	if t <= 1 || t > n {
+		return shares, pol, fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, n)
 	}
 
-	if !field.IsGroupElement(big.NewInt(int64(n))) {
-		return shares, pol, fmt.Errorf("Invalid value for n")
+	// IDs 1 to n must be distinct, non-zero field elements
+	if field.Order().Cmp(big.NewInt(int64(n))) <= 0 {
+		return shares, pol, fmt.Errorf("%w: order %d for %d shares", ErrFieldTooSmall, field.Order(), n)
 	}
 
-	if !field.IsGroupElement(secret) {
-		return shares, pol, fmt.Errorf("Invalid value for secret")
+	if secret == nil || !field.IsGroupElement(secret) {
+		return shares, pol, fmt.Errorf("%w: secret", ErrNotInField)
 	}
 
-	pol, err := field.RandomPolynomial(t - 1)
+	pol, err := gf.RandomPolynomial(t-1, field)
 	if err != nil {
 		return shares, pol, err
 	}
@@ -46,47 +72,307 @@ func TOutOfN(secret *big.Int, t int, n int, field gf.GF) ([]Share, gf.Polynomial
 	// We'll use the secret as the first coefficient, so p(0) = secret
 	pol.Coefficients[0] = secret
 
+	secretID, err := newSecretID()
+	if err != nil {
+		return shares, pol, err
+	}
+
+	// Share of participant `i` will be p(i)
+	shares = make([]Share, n)
+	xs := make([]*big.Int, n)
+	for i := range xs {
+		xs[i] = big.NewInt(int64(i + 1))
+	}
+
+	ys, err := pol.EvaluateMany(xs)
+	if err != nil {
+		return shares, pol, err
+	}
+
 	for i := 0; i < n; i++ {
-		// Share of participant `i` will be p(i)
-		x := i + 1
-		result, err := pol.Evaluate(big.NewInt(int64(x)))
-		if err != nil {
-			return shares, pol, err
+		shares[i] = Share{
+			ID:        i + 1,
+			Value:     ys[i],
+			Threshold: t,
+			SecretID:  secretID,
+			Order:     field.Order(),
 		}
+	}
+
+	return shares, pol, nil
+}
+
+// dealShares evaluates a random polynomial `p` of degree `t - 1` with
+// `p(0) = value` at each of the given IDs.
+//
+// It is required that:
+// - 1 < t <= len(ids)
+// - ids are unique, non-zero elements of the field
+//
+// Returns a slice containing one share for each ID, and the polynomial used to
+// calculate the shares.
+// An error is returned if any of the requirements are violated, see
+// `checkIDs`.
+func dealShares(value *big.Int, t int, ids []int, field gf.Field) ([]Share, gf.Polynomial, error) {
+	var pol gf.Polynomial
+	shares := make([]Share, len(ids))
+
+	if t <= 1 || t > len(ids) {
+		return shares, pol, fmt.Errorf("%w: t = %d, n = %d", ErrThreshold, t, len(ids))
+	}
+
+	if err := checkIDs(ids, field); err != nil {
+		return shares, pol, err
+	}
+
+	pol, err := gf.RandomPolynomial(t-1, field)
+	if err != nil {
+		return shares, pol, err
+	}
+	pol.Coefficients[0] = value
+
+	xs := make([]*big.Int, len(ids))
+	for i, id := range ids {
+		xs[i] = big.NewInt(int64(id))
+	}
+
+	ys, err := pol.EvaluateMany(xs)
+	if err != nil {
+		return shares, pol, err
+	}
 
-		shares[i] = Share{ID: x, Value: result}
+	for i, id := range ids {
+		shares[i] = Share{ID: id, Value: ys[i], Threshold: t}
 	}
 
 	return shares, pol, nil
 }
 
+// InconsistentSharesError is returned if more than `t` shares are supplied
+// for recovery, and some of them do not lie on the polynomial defined by the
+// first `t` shares.
+//
+// The error only shows that the shares do not all lie on one polynomial, not
+// which of them are corrupted: If one of the first `t` shares is corrupted,
+// the honest remaining shares are reported instead. Use
+// `TOutOfNRecoverRobust` to identify corrupted shares.
+type InconsistentSharesError struct {
+	// IDs of the shares which are inconsistent with the first `t` shares,
+	// which are not necessarily the corrupted ones
+	IDs []int
+}
+
+func (err *InconsistentSharesError) Error() string {
+	return fmt.Sprintf("Shares with IDs %v are inconsistent with the other shares", err.IDs)
+}
+
 // TOutOfNRecover recovers a secret from t out of n shares.
 //
-// The slice of shares must be *exactly* `t` *unique* shares. If there are any
-// more or less, an incorrect value will be reconstructed.
+// The threshold `t` is taken from the shares, all of which must agree on it.
+// Any number of at least `t` shares may be supplied, see
+// `TOutOfNRecoverThreshold`.
+//
+// Shares without a threshold, eg. ones created by hand, are assumed to be
+// *exactly* `t` shares. If there are any more or less, an incorrect value will
+// be reconstructed.
+//
+// Returns an error if shares are not unique, disagree on the threshold, or are
+// inconsistent.
+func TOutOfNRecover(shares []Share, field gf.Field) (*big.Int, error) {
+	return TOutOfNRecoverCached(shares, gf.NewLagrangeCache(field))
+}
+
+// TOutOfNRecoverCached recovers a secret from t out of n shares like
+// `TOutOfNRecover`, reusing the Lagrange coefficients cached in `cache`.
+//
+// Recovering many secrets from shares with the same IDs, eg. the chunks of a
+// long secret, then only calculates the coefficients once.
+//
+// Returns an error if shares are not unique, disagree on the threshold, or are
+// inconsistent.
+func TOutOfNRecoverCached(shares []Share, cache *gf.LagrangeCache) (*big.Int, error) {
+	t, err := recoveryThreshold(shares)
+	if err != nil {
+		return &big.Int{}, err
+	}
+
+	return recoverThreshold(shares, t, cache)
+}
+
+// recoveryThreshold determines the threshold of the given shares, all of which
+// must agree on it. Shares without a threshold are assumed to be exactly `t`
+// shares.
+func recoveryThreshold(shares []Share) (int, error) {
+	if len(shares) == 0 {
+		return 0, fmt.Errorf("No shares supplied")
+	}
+
+	t := shares[0].Threshold
+	for _, share := range shares {
+		if share.Threshold != t {
+			return 0, fmt.Errorf("Share with ID %d has threshold %d; expected %d", share.ID, share.Threshold, t)
+		}
+	}
+
+	if t == 0 {
+		t = len(shares)
+	}
+
+	return t, nil
+}
+
+// TOutOfNRecoverThreshold recovers a secret from at least t out of n shares.
+//
+// The secret is interpolated from the first `t` shares. All remaining shares
+// are then checked to lie on the same polynomial, which detects a mismatch,
+// but not which shares caused it. Use `TOutOfNRecoverRobust` to recover from
+// more than `t` shares, some of which may be corrupted.
 //
-// Returns an error if shares are not unique.
-func TOutOfNRecover(shares []Share, field gf.GF) (*big.Int, error) {
+// Returns an error if shares are not unique, if fewer than `t` shares are
+// supplied, or if their metadata shows that they do not belong to the same
+// secret in the field `field` with threshold `t`. An
+// `*InconsistentSharesError` is returned if any of the remaining shares do
+// not lie on the polynomial defined by the first `t` shares.
+func TOutOfNRecoverThreshold(shares []Share, t int, field gf.Field) (*big.Int, error) {
+	return recoverThreshold(shares, t, gf.NewLagrangeCache(field))
+}
+
+// recoverThreshold recovers a secret from at least `t` shares, see
+// `TOutOfNRecoverThreshold`, using the Lagrange bases cached in `cache`.
+func recoverThreshold(shares []Share, t int, cache *gf.LagrangeCache) (*big.Int, error) {
 	var sum = &big.Int{}
+	field := cache.Field
+
+	if t < 1 {
+		return sum, fmt.Errorf("%w: t = %d", ErrThreshold, t)
+	}
+
+	if len(shares) < t {
+		return sum, fmt.Errorf("%w: at least %d shares required; got %d", ErrThreshold, t, len(shares))
+	}
+
+	if err := checkThreshold(shares, t); err != nil {
+		return sum, err
+	}
+
+	if err := checkSameSecret(shares, field); err != nil {
+		return sum, err
+	}
+
+	if err := checkIDs(shareIDs(shares), field); err != nil {
+		return sum, err
+	}
 
-	seen := make(map[int]bool)
 	xs := make([]*big.Int, len(shares))
+	ys := make([]*big.Int, len(shares))
 	for i, share := range shares {
-		if _, ok := seen[share.ID]; ok {
-			// Share with given ID already seen
-			return sum, fmt.Errorf("Duplicate share with ID %d supplied", share.ID)
+		if share.Value == nil || !field.IsGroupElement(share.Value) {
+			return sum, fmt.Errorf("%w: value of share with ID %d", ErrNotInField, share.ID)
 		}
-		seen[share.ID] = true
 		xs[i] = big.NewInt(int64(share.ID))
+		ys[i] = share.Value
+	}
+
+	basis, err := cache.Basis(xs[:t])
+	if err != nil {
+		return sum, err
 	}
 
-	for j := 0; j < len(shares); j++ {
-		var term = &big.Int{}
-		term.Set(shares[j].Value)                   // y_i
-		basePoly := gf.BasePolynomial(j, xs, field) // l_j(0)
-		term = field.Mul(term, basePoly)            // y_i * l_j(0)
-		sum = field.Add(sum, term)
+	sum, err = basis.InterpolateAt(ys[:t], big.NewInt(0))
+	if err != nil {
+		return sum, err
+	}
+
+	var inconsistent []int
+	for k := t; k < len(shares); k++ {
+		y, err := basis.InterpolateAt(ys[:t], xs[k])
+		if err != nil {
+			return sum, err
+		}
+
+		if y.Cmp(shares[k].Value) != 0 {
+			inconsistent = append(inconsistent, shares[k].ID)
+		}
+	}
+	if len(inconsistent) > 0 {
+		return sum, &InconsistentSharesError{IDs: inconsistent}
 	}
 
 	return sum, nil
 }
+
+// checkSameSecret verifies that the metadata of all shares indicates that they
+// are shares of the same secret in the field `field`.
+//
+// Returns an error if shares disagree on the secret identifier or field order,
+// or if their field order does not match `field`.
+func checkSameSecret(shares []Share, field gf.Field) error {
+	if len(shares) == 0 {
+		return nil
+	}
+
+	first := shares[0]
+	for _, share := range shares {
+		if !bytes.Equal(share.SecretID, first.SecretID) {
+			return fmt.Errorf("Share with ID %d belongs to secret %x; expected %x", share.ID, share.SecretID, first.SecretID)
+		}
+
+		if (share.Order == nil) != (first.Order == nil) {
+			return fmt.Errorf("Share with ID %d does not match field of other shares", share.ID)
+		}
+
+		if share.Order != nil && share.Order.Cmp(field.Order()) != 0 {
+			return fmt.Errorf("Share with ID %d is in field of order %d; expected %d", share.ID, share.Order, field.Order())
+		}
+	}
+
+	return nil
+}
+
+// checkThreshold checks that all shares which carry a threshold were created
+// with threshold `t`. Shares without a threshold are assumed to match.
+//
+// Returns an error wrapping `ErrThreshold` otherwise.
+func checkThreshold(shares []Share, t int) error {
+	for _, share := range shares {
+		if share.Threshold != 0 && share.Threshold != t {
+			return fmt.Errorf("%w: share with ID %d has threshold %d; expected %d", ErrThreshold, share.ID, share.Threshold, t)
+		}
+	}
+
+	return nil
+}
+
+// checkIDs checks that share IDs are unique, non-zero elements of the field.
+//
+// Returns an error wrapping `ErrZeroID`, `ErrNotInField` or `ErrDuplicateID`
+// otherwise.
+func checkIDs(ids []int, field gf.Field) error {
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if id == 0 {
+			return ErrZeroID
+		}
+
+		if id < 0 || !field.IsGroupElement(big.NewInt(int64(id))) {
+			return fmt.Errorf("%w: share ID %d", ErrNotInField, id)
+		}
+
+		if seen[id] {
+			return fmt.Errorf("%w %d supplied", ErrDuplicateID, id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// shareIDs returns the IDs of the shares.
+func shareIDs(shares []Share) []int {
+	ids := make([]int, len(shares))
+	for i, share := range shares {
+		ids[i] = share.ID
+	}
+
+	return ids
+}
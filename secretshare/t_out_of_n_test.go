@@ -1,8 +1,10 @@
 package secretshare
 
 import (
+	"errors"
 	"github.com/lavode/secret-sharing/gf"
 	"math/big"
+	"reflect"
 	"testing"
 )
 
@@ -66,6 +68,59 @@ func TestTOutOfN(t *testing.T) {
 	}
 }
 
+func TestTOutOfNGF256Field(t *testing.T) {
+	field := gf.GF256{}
+	secret := big.NewInt(0xa7)
+
+	shares, _, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	sharesSubset := []Share{
+		shares[1],
+		shares[3],
+		shares[4],
+	}
+	reconstructed, err := TOutOfNRecover(sharesSubset, field)
+	if err != nil {
+		t.Fatalf("Error verifying shares: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
+func TestTOutOfNMontgomeryField(t *testing.T) {
+	field, err := gf.NewMontgomery(mersenne127().P)
+	if err != nil {
+		t.Fatalf("Error creating Montgomery field: %v", err)
+	}
+	secret := big.NewInt(1234567890)
+
+	shares, _, err := TOutOfN(secret, 3, 5, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	reconstructed, err := TOutOfNRecover(shares, field)
+	if err != nil {
+		t.Fatalf("Error verifying shares: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+
+	// Shares are interchangeable with ones of the equivalent big.Int field
+	reconstructed, err = TOutOfNRecover(shares, mersenne127())
+	if err != nil {
+		t.Fatalf("Error verifying shares: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+}
+
 func TestTOutOfNInvalidInputs(t *testing.T) {
 	field := gf.GF{P: big.NewInt(53)}
 	secret := big.NewInt(42)
@@ -73,28 +128,58 @@ func TestTOutOfNInvalidInputs(t *testing.T) {
 	n := 5
 
 	_, _, err := TOutOfN(secret, 1, n, field)
-	if err == nil {
-		t.Errorf("Expected error if t <= 1; got none")
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t <= 1; got %v", err)
 	}
 
 	_, _, err = TOutOfN(secret, n+1, n, field)
-	if err == nil {
-		t.Errorf("Expected error if t > n; got none")
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t > n; got %v", err)
+	}
+
+	_, _, err = TOutOfN(secret, 1, -1, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if n < 0; got %v", err)
 	}
 
 	_, _, err = TOutOfN(secret, 54, n, field)
-	if err == nil {
-		t.Errorf("Expected error if t not a group element; got none")
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if t not a group element; got %v", err)
 	}
 
 	_, _, err = TOutOfN(secret, tShares, 54, field)
-	if err == nil {
-		t.Errorf("Expected error if n not a group element; got none")
+	if !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected ErrFieldTooSmall if n not a group element; got %v", err)
 	}
 
 	_, _, err = TOutOfN(big.NewInt(55), tShares, n, field)
-	if err == nil {
-		t.Errorf("Expected error if secret not a group element; got none")
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if secret not a group element; got %v", err)
+	}
+}
+
+func TestTOutOfNSmallField(t *testing.T) {
+	field := gf.GF{P: big.NewInt(5)}
+	secret := big.NewInt(3)
+
+	// Share IDs 1 to 4 are the only non-zero elements
+	shares, _, err := TOutOfN(secret, 2, 4, field)
+	if err != nil {
+		t.Fatalf("Error creating 2-out-of-4 shares in GF(5): %v", err)
+	}
+
+	recovered, err := TOutOfNRecover(shares, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if recovered.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, recovered)
+	}
+
+	// Share 5 would be p(0) = secret
+	_, _, err = TOutOfN(secret, 2, 5, field)
+	if !errors.Is(err, ErrFieldTooSmall) {
+		t.Errorf("Expected ErrFieldTooSmall if n = p; got %v", err)
 	}
 }
 
@@ -103,9 +188,9 @@ func TestTOutOfNRecover(t *testing.T) {
 	secret := big.NewInt(42)
 
 	shares := []Share{
-		{1, big.NewInt(37)},
-		{2, big.NewInt(48)},
-		{5, big.NewInt(18)},
+		{ID: 1, Value: big.NewInt(37)},
+		{ID: 2, Value: big.NewInt(48)},
+		{ID: 5, Value: big.NewInt(18)},
 	}
 	actual, err := TOutOfNRecover(shares, field)
 	if err != nil {
@@ -116,9 +201,9 @@ func TestTOutOfNRecover(t *testing.T) {
 	}
 
 	shares = []Share{
-		{1, big.NewInt(37)},
-		{3, big.NewInt(22)},
-		{4, big.NewInt(12)},
+		{ID: 1, Value: big.NewInt(37)},
+		{ID: 3, Value: big.NewInt(22)},
+		{ID: 4, Value: big.NewInt(12)},
 	}
 	actual, err = TOutOfNRecover(shares, field)
 	if err != nil {
@@ -131,11 +216,11 @@ func TestTOutOfNRecover(t *testing.T) {
 	field = gf.GF{P: big.NewInt(127)}
 	secret = big.NewInt(86)
 	shares = []Share{
-		{1, big.NewInt(30)},
-		{3, big.NewInt(101)},
-		{5, big.NewInt(109)},
-		{6, big.NewInt(35)},
-		{9, big.NewInt(86)},
+		{ID: 1, Value: big.NewInt(30)},
+		{ID: 3, Value: big.NewInt(101)},
+		{ID: 5, Value: big.NewInt(109)},
+		{ID: 6, Value: big.NewInt(35)},
+		{ID: 9, Value: big.NewInt(86)},
 	}
 	actual, err = TOutOfNRecover(shares, field)
 	if err != nil {
@@ -146,15 +231,173 @@ func TestTOutOfNRecover(t *testing.T) {
 	}
 }
 
+func TestTOutOfNRecoverAllShares(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	secret := big.NewInt(42)
+
+	shares, _, err := TOutOfN(secret, 3, 6, field)
+	if err != nil {
+		t.Fatalf("Error creating t-out-of-n share: %v", err)
+	}
+
+	for _, share := range shares {
+		if share.Threshold != 3 {
+			t.Errorf("Expected share to carry threshold 3; got %d", share.Threshold)
+		}
+	}
+
+	reconstructed, err := TOutOfNRecover(shares, field)
+	if err != nil {
+		t.Fatalf("Error recovering secret: %v", err)
+	}
+	if secret.Cmp(reconstructed) != 0 {
+		t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+	}
+
+	_, err = TOutOfNRecover(shares[:2], field)
+	if err == nil {
+		t.Errorf("Expected error if fewer than t shares given; got none")
+	}
+
+	shares[4].Value = field.Add(shares[4].Value, big.NewInt(1))
+	shares[5].Value = field.Add(shares[5].Value, big.NewInt(7))
+	_, err = TOutOfNRecover(shares, field)
+
+	var inconsistent *InconsistentSharesError
+	if !errors.As(err, &inconsistent) {
+		t.Fatalf("Expected InconsistentSharesError; got %v", err)
+	}
+	if !reflect.DeepEqual(inconsistent.IDs, []int{5, 6}) {
+		t.Errorf("Expected shares [5 6] to be inconsistent; got %v", inconsistent.IDs)
+	}
+}
+
+func TestTOutOfNRecoverThreshold(t *testing.T) {
+	field := gf.GF{P: big.NewInt(53)}
+	secret := big.NewInt(42)
+
+	// Shares without threshold, of which the last is inconsistent
+	shares := []Share{
+		{ID: 1, Value: big.NewInt(37)},
+		{ID: 2, Value: big.NewInt(48)},
+		{ID: 5, Value: big.NewInt(18)},
+		{ID: 3, Value: big.NewInt(21)},
+	}
+
+	actual, err := TOutOfNRecoverThreshold(shares, 3, field)
+	if err == nil {
+		t.Errorf("Expected error for inconsistent share; got none")
+	}
+
+	shares[3].Value = big.NewInt(22)
+	actual, err = TOutOfNRecoverThreshold(shares, 3, field)
+	if err != nil {
+		t.Fatalf("Error while recovering secret: %v", err)
+	}
+	if actual.Cmp(secret) != 0 {
+		t.Errorf("Expected to recover %d; got %d", secret, actual)
+	}
+
+	_, err = TOutOfNRecoverThreshold(shares, 0, field)
+	if err == nil {
+		t.Errorf("Expected error if t < 1; got none")
+	}
+
+	_, err = TOutOfNRecoverThreshold(shares, 5, field)
+	if err == nil {
+		t.Errorf("Expected error if fewer than t shares given; got none")
+	}
+
+	shares[0].Threshold = 2
+	_, err = TOutOfNRecoverThreshold(shares, 3, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if share has a different threshold; got %v", err)
+	}
+}
+
 func TestTOutOfNRecoverInvalidInputs(t *testing.T) {
 	field := gf.GF{P: big.NewInt(53)}
 	shares := []Share{
-		{1, big.NewInt(10)},
-		{2, big.NewInt(11)},
-		{1, big.NewInt(12)},
+		{ID: 1, Value: big.NewInt(10)},
+		{ID: 2, Value: big.NewInt(11)},
+		{ID: 1, Value: big.NewInt(12)},
 	}
 	_, err := TOutOfNRecover(shares, field)
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Fatalf("Expected ErrDuplicateID if duplicate shares given; got %v", err)
+	}
+
+	_, err = TOutOfNRecover([]Share{}, field)
+	if err == nil {
+		t.Errorf("Expected error if no shares given; got none")
+	}
+
+	shares = []Share{
+		{ID: 0, Value: big.NewInt(10)},
+		{ID: 1, Value: big.NewInt(11)},
+	}
+	_, err = TOutOfNRecover(shares, field)
+	if !errors.Is(err, ErrZeroID) {
+		t.Errorf("Expected ErrZeroID if share with ID 0 given; got %v", err)
+	}
+
+	shares = []Share{
+		{ID: 1, Value: big.NewInt(10)},
+		{ID: 54, Value: big.NewInt(11)},
+	}
+	_, err = TOutOfNRecover(shares, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if share ID not in field; got %v", err)
+	}
+
+	shares = []Share{
+		{ID: 1, Value: big.NewInt(10)},
+		{ID: 2, Value: big.NewInt(53)},
+	}
+	_, err = TOutOfNRecover(shares, field)
+	if !errors.Is(err, ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if share value not in field; got %v", err)
+	}
+
+	_, err = TOutOfNRecoverThreshold(shares[:1], 2, field)
+	if !errors.Is(err, ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if fewer than t shares given; got %v", err)
+	}
+
+	shares = []Share{
+		{ID: 1, Value: big.NewInt(10), Threshold: 2},
+		{ID: 2, Value: big.NewInt(11), Threshold: 3},
+		{ID: 3, Value: big.NewInt(12), Threshold: 2},
+	}
+	_, err = TOutOfNRecover(shares, field)
+	if err == nil {
+		t.Errorf("Expected error if shares disagree on threshold; got none")
+	}
+}
+
+func TestTOutOfNRecoverCached(t *testing.T) {
+	field := gf.GF{P: big.NewInt(1019)}
+	cache := gf.NewLagrangeCache(field)
+
+	// Recover several secrets from shares with identical IDs, all of which
+	// share one Lagrange basis.
+	for _, secret := range []int64{0, 42, 1018} {
+		shares, _, err := TOutOfN(big.NewInt(secret), 3, 5, field)
+		if err != nil {
+			t.Fatalf("Error creating t-out-of-n share: %v", err)
+		}
+
+		reconstructed, err := TOutOfNRecoverCached(shares[1:], cache)
+		if err != nil {
+			t.Fatalf("Error recovering secret: %v", err)
+		}
+		if reconstructed.Cmp(big.NewInt(secret)) != 0 {
+			t.Errorf("Reconstructed secret %d does not match %d", reconstructed, secret)
+		}
+	}
+
+	_, err := TOutOfNRecoverCached([]Share{}, cache)
 	if err == nil {
-		t.Fatalf("Expected error if duplicate shares given; got none")
+		t.Errorf("Expected error if no shares given; got none")
 	}
 }
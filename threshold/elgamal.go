@@ -0,0 +1,319 @@
+// Package threshold implements threshold cryptosystems on top of Shamir secret
+// sharing. The private key is shared among n parties using the `secretshare`
+// package, any t of which cooperate to use it, without the key ever being
+// reconstructed.
+//
+// FROST signatures use the P-256 curve through the low-level methods of
+// `elliptic.Curve`, such as `ScalarMult` and `Add`. These are deprecated, but
+// the standard library offers no other way to multiply arbitrary points by a
+// scalar or to add points, as `crypto/ecdh` and `crypto/ecdsa` only expose
+// complete key exchanges and signatures. For P-256, the deprecated methods
+// are backed by the same constant-time implementation as those packages.
+package threshold
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+)
+
+// ElGamalPublicKey is the public key of an ElGamal key pair whose private key
+// is shared among n parties.
+type ElGamalPublicKey struct {
+	// Group the key is in
+	Group gf.SchnorrGroup
+	// Public key `y = g^x` of the private key `x`
+	Y *big.Int
+	// Number of parties required to decrypt
+	Threshold int
+	// Verification keys `g^{s_i}` of each party's key share `s_i`, by ID
+	VerificationKeys map[int]*big.Int
+}
+
+// ElGamalCiphertext is an ElGamal encryption `(g^r, m * y^r)` of a message m.
+type ElGamalCiphertext struct {
+	C1 *big.Int
+	C2 *big.Int
+}
+
+// DLEQProof is a non-interactive Chaum-Pedersen proof that two group elements
+// `a = g^s` and `b = h^s` share the same discrete logarithm `s`, with respect
+// to the bases `g` and `h`.
+type DLEQProof struct {
+	// Fiat-Shamir challenge
+	Challenge *big.Int
+	// Response `z = k - c * s` to the challenge
+	Response *big.Int
+}
+
+// PartialDecryption is one party's contribution `c1^{s_i}` to the decryption
+// of a ciphertext.
+type PartialDecryption struct {
+	// ID of the party
+	ID int
+	// Partial decryption `c1^{s_i}`
+	Value *big.Int
+	// Optional proof of correct partial decryption, nil if none was created
+	Proof *DLEQProof
+}
+
+// ElGamalGenerateKey generates an ElGamal key pair, whose private key is
+// shared among n parties using `secretshare.TOutOfN` over the scalar field
+// GF(q) of the group.
+//
+// The dealer generating the key learns the private key, and must discard it
+// along with all shares it does not hand out.
+//
+// Returns the public key, including each party's verification key, and the
+// key shares of all parties.
+// An error is returned if any of the requirements of `secretshare.TOutOfN` are
+// violated.
+func ElGamalGenerateKey(t int, n int, group gf.SchnorrGroup) (ElGamalPublicKey, []secretshare.Share, error) {
+	pub := ElGamalPublicKey{Group: group, Threshold: t}
+	field := group.ScalarField()
+
+	var x = &big.Int{}
+	for x.Sign() == 0 {
+		var err error
+		if x, err = field.Rand(); err != nil {
+			return pub, nil, err
+		}
+	}
+
+	shares, _, err := secretshare.TOutOfN(x, t, n, field)
+	if err != nil {
+		return pub, nil, err
+	}
+
+	pub.Y = group.Commit(x)
+	pub.VerificationKeys = make(map[int]*big.Int, n)
+	for _, share := range shares {
+		pub.VerificationKeys[share.ID] = group.Commit(share.Value) // g^{s_i}
+	}
+
+	return pub, shares, nil
+}
+
+// ElGamalEncrypt encrypts a message `m` to the public key `pub`.
+//
+// Messages must be elements of the group, eg. `g^m` for small integers m, or
+// a symmetric key encoded as a group element.
+//
+// Returns an error if the message is not an element of the group.
+func ElGamalEncrypt(m *big.Int, pub ElGamalPublicKey) (ElGamalCiphertext, error) {
+	var ct ElGamalCiphertext
+	group := pub.Group
+
+	if !group.IsElement(m) {
+		return ct, fmt.Errorf("Message %d is not an element of the group", m)
+	}
+
+	r, err := group.ScalarField().Rand()
+	if err != nil {
+		return ct, err
+	}
+
+	ct.C1 = group.Commit(r)                   // g^r
+	ct.C2 = group.Mul(m, group.Exp(pub.Y, r)) // m * y^r
+
+	return ct, nil
+}
+
+// ElGamalPartialDecrypt calculates the partial decryption `c1^{s_i}` of a
+// ciphertext with the key share `s_i`.
+//
+// If `prove` is set, a Chaum-Pedersen proof is attached, showing that the
+// partial decryption uses the same key share as the party's verification key.
+//
+// Returns an error if the key share is not an element of the scalar field, or
+// if the first component of the ciphertext is not an element of the group, as
+// raising it to the key share could leak information about the share.
+func ElGamalPartialDecrypt(share secretshare.Share, ct ElGamalCiphertext, group gf.SchnorrGroup, prove bool) (PartialDecryption, error) {
+	partial := PartialDecryption{ID: share.ID}
+
+	if share.Value == nil || !group.ScalarField().IsGroupElement(share.Value) {
+		return partial, fmt.Errorf("%w: value of share with ID %d", secretshare.ErrNotInField, share.ID)
+	}
+
+	if ct.C1 == nil || !group.IsElement(ct.C1) {
+		return partial, fmt.Errorf("Ciphertext is not in the group")
+	}
+
+	partial.Value = group.Exp(ct.C1, share.Value) // c1^{s_i}
+
+	if prove {
+		proof, err := proveDLEQ(share.Value, group.G, ct.C1, group)
+		if err != nil {
+			return partial, err
+		}
+		partial.Proof = &proof
+	}
+
+	return partial, nil
+}
+
+// VerifyPartialDecryption verifies the proof of a partial decryption against
+// the party's verification key.
+//
+// Partial decryptions without a proof fail verification.
+func VerifyPartialDecryption(partial PartialDecryption, ct ElGamalCiphertext, pub ElGamalPublicKey) bool {
+	vk, ok := pub.VerificationKeys[partial.ID]
+	if !ok || partial.Proof == nil || partial.Value == nil {
+		return false
+	}
+
+	return verifyDLEQ(*partial.Proof, pub.Group.G, vk, ct.C1, partial.Value, pub.Group)
+}
+
+// ElGamalCombine combines at least t partial decryptions into the plaintext.
+//
+// The partials are combined as `D = Product for i [ (c1^{s_i})^{l_i(0)} ]`,
+// which equals `c1^x = y^r` without reconstructing `x`, and the message is
+// `m = c2 / D`.
+//
+// All supplied partial decryptions are checked, and the first t of them are
+// combined. If `requireProofs` is set, each of them must carry a valid proof,
+// such that a party submitting an incorrect partial decryption is detected.
+// Otherwise, proofs are only verified if attached, and partial decryptions
+// without a proof are trusted: an incorrect one results in a wrong plaintext,
+// and no error.
+//
+// Returns an error if the ciphertext is not in the group, if fewer than t
+// partial decryptions are supplied, if their IDs are not unique, valid IDs,
+// or if any of their proofs is missing although required, or fails
+// verification.
+func ElGamalCombine(partials []PartialDecryption, ct ElGamalCiphertext, pub ElGamalPublicKey, requireProofs bool) (*big.Int, error) {
+	var m = &big.Int{}
+	group := pub.Group
+
+	if ct.C1 == nil || !group.IsElement(ct.C1) || ct.C2 == nil || !group.IsElement(ct.C2) {
+		return m, fmt.Errorf("Ciphertext is not in the group")
+	}
+
+	if len(partials) < pub.Threshold {
+		return m, fmt.Errorf("%w: at least %d partial decryptions required; got %d", secretshare.ErrThreshold, pub.Threshold, len(partials))
+	}
+
+	for _, partial := range partials {
+		if partial.Value == nil || !group.IsElement(partial.Value) {
+			return m, fmt.Errorf("Partial decryption of ID %d is not in the group", partial.ID)
+		}
+
+		if partial.Proof == nil && requireProofs {
+			return m, fmt.Errorf("Partial decryption of ID %d carries no proof", partial.ID)
+		}
+
+		if partial.Proof != nil && !VerifyPartialDecryption(partial, ct, pub) {
+			return m, fmt.Errorf("Partial decryption of ID %d failed verification", partial.ID)
+		}
+	}
+
+	partials = partials[:pub.Threshold]
+	xs := make([]*big.Int, len(partials))
+	for i, partial := range partials {
+		xs[i] = big.NewInt(int64(partial.ID))
+	}
+
+	coeffs, err := lagrangeAtZero(xs, group.ScalarField())
+	if err != nil {
+		return m, err
+	}
+
+	var d = big.NewInt(1)
+	for i, partial := range partials {
+		d = group.Mul(d, group.Exp(partial.Value, coeffs[i])) // (c1^{s_i})^{l_i(0)}
+	}
+
+	// m = c2 * D^-1, where D^-1 = D^{q-1} as D is of order q
+	inv := group.Exp(d, new(big.Int).Sub(group.Q, big.NewInt(1)))
+	m = group.Mul(ct.C2, inv)
+
+	return m, nil
+}
+
+// lagrangeAtZero calculates the Lagrange coefficients `l_i(0)` of the share
+// IDs `xs`.
+//
+// Returns an error if any ID is zero, as the party would hold the key itself,
+// or if the IDs are not unique elements of the field.
+func lagrangeAtZero(xs []*big.Int, field gf.Field) ([]*big.Int, error) {
+	for _, x := range xs {
+		if x.Sign() == 0 {
+			return nil, secretshare.ErrZeroID
+		}
+	}
+
+	return gf.LagrangeCoefficients(xs, big.NewInt(0), field)
+}
+
+// proveDLEQ creates a Chaum-Pedersen proof that `g^s` and `h^s` share the
+// discrete logarithm `s`.
+//
+// With a random nonce `k`, the commitments are `g^k` and `h^k`, the challenge
+// `c` is the hash of the statement and commitments, and the response is
+// `z = k - c * s`.
+func proveDLEQ(s *big.Int, g *big.Int, h *big.Int, group gf.SchnorrGroup) (DLEQProof, error) {
+	var proof DLEQProof
+	field := group.ScalarField()
+
+	k, err := field.Rand()
+	if err != nil {
+		return proof, err
+	}
+
+	a := group.Exp(g, s)
+	b := group.Exp(h, s)
+	proof.Challenge = challenge(field, group.P, g, a, h, b, group.Exp(g, k), group.Exp(h, k))
+	proof.Response = field.Sub(k, field.Mul(proof.Challenge, s)) // k - c * s
+
+	return proof, nil
+}
+
+// verifyDLEQ verifies a Chaum-Pedersen proof that `a = g^s` and `b = h^s`
+// share the discrete logarithm `s`.
+//
+// The commitments are recovered as `g^z * a^c = g^k` and `h^z * b^c = h^k`,
+// which must hash to the challenge.
+func verifyDLEQ(proof DLEQProof, g *big.Int, a *big.Int, h *big.Int, b *big.Int, group gf.SchnorrGroup) bool {
+	field := group.ScalarField()
+
+	if proof.Challenge == nil || proof.Response == nil {
+		return false
+	}
+
+	if !field.IsGroupElement(proof.Challenge) || !field.IsGroupElement(proof.Response) {
+		return false
+	}
+
+	if !group.IsElement(a) || !group.IsElement(b) {
+		return false
+	}
+
+	ka := group.Mul(group.Exp(g, proof.Response), group.Exp(a, proof.Challenge)) // g^k
+	kb := group.Mul(group.Exp(h, proof.Response), group.Exp(b, proof.Challenge)) // h^k
+
+	return challenge(field, group.P, g, a, h, b, ka, kb).Cmp(proof.Challenge) == 0
+}
+
+// challenge derives a Fiat-Shamir challenge in the field from a list of
+// values, each encoded with the fixed width of `bound`.
+//
+// The digest is reduced modulo the order of the field, which is negligibly
+// biased for orders close to a power of two, and acceptable for the proofs of
+// this package otherwise.
+func challenge(field gf.Field, bound *big.Int, values ...*big.Int) *big.Int {
+	size := (bound.BitLen() + 7) / 8
+
+	h := sha256.New()
+	for _, value := range values {
+		h.Write(value.FillBytes(make([]byte, size)))
+	}
+
+	var c = &big.Int{}
+	c.SetBytes(h.Sum(nil))
+	c.Mod(c, field.Order())
+
+	return c
+}
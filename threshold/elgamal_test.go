@@ -0,0 +1,200 @@
+package threshold
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+	"testing"
+)
+
+// testGroup returns the Schnorr group of order 1019 in Z*_2039.
+func testGroup() gf.SchnorrGroup {
+	return gf.SchnorrGroup{
+		P: big.NewInt(2039),
+		Q: big.NewInt(1019),
+		G: big.NewInt(4),
+	}
+}
+
+func TestElGamal(t *testing.T) {
+	groups := map[string]gf.SchnorrGroup{
+		"small":    testGroup(),
+		"modp2048": gf.MODP2048Group(),
+	}
+
+	for name, group := range groups {
+		pub, shares, err := ElGamalGenerateKey(3, 5, group)
+		if err != nil {
+			t.Fatalf("%s: Error generating key: %v", name, err)
+		}
+
+		m := group.Commit(big.NewInt(42))
+		ct, err := ElGamalEncrypt(m, pub)
+		if err != nil {
+			t.Fatalf("%s: Error encrypting message: %v", name, err)
+		}
+
+		// Any 3 out of 5 parties may decrypt
+		for _, ids := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+			partials := make([]PartialDecryption, len(ids))
+			for i, id := range ids {
+				partials[i], err = ElGamalPartialDecrypt(shares[id], ct, group, true)
+				if err != nil {
+					t.Fatalf("%s: Error creating partial decryption: %v", name, err)
+				}
+
+				if !VerifyPartialDecryption(partials[i], ct, pub) {
+					t.Errorf("%s: Expected partial decryption of ID %d to pass verification; did not", name, partials[i].ID)
+				}
+			}
+
+			decrypted, err := ElGamalCombine(partials, ct, pub, true)
+			if err != nil {
+				t.Fatalf("%s: Error combining partial decryptions: %v", name, err)
+			}
+			if decrypted.Cmp(m) != 0 {
+				t.Errorf("%s: Expected to decrypt %d; got %d", name, m, decrypted)
+			}
+		}
+	}
+}
+
+func TestElGamalWithoutProofs(t *testing.T) {
+	group := testGroup()
+	pub, shares, err := ElGamalGenerateKey(2, 3, group)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+
+	m := group.Commit(big.NewInt(7))
+	ct, err := ElGamalEncrypt(m, pub)
+	if err != nil {
+		t.Fatalf("Error encrypting message: %v", err)
+	}
+
+	partials := make([]PartialDecryption, 3)
+	for i, share := range shares {
+		partials[i], err = ElGamalPartialDecrypt(share, ct, group, false)
+		if err != nil {
+			t.Fatalf("Error creating partial decryption: %v", err)
+		}
+
+		if VerifyPartialDecryption(partials[i], ct, pub) {
+			t.Errorf("Expected partial decryption without proof to fail verification; did not")
+		}
+	}
+
+	// Surplus partial decryptions are ignored
+	decrypted, err := ElGamalCombine(partials, ct, pub, false)
+	if err != nil {
+		t.Fatalf("Error combining partial decryptions: %v", err)
+	}
+	if decrypted.Cmp(m) != 0 {
+		t.Errorf("Expected to decrypt %d; got %d", m, decrypted)
+	}
+
+	_, err = ElGamalCombine(partials, ct, pub, true)
+	if err == nil {
+		t.Errorf("Expected error if proofs are required but missing; got none")
+	}
+
+	// An incorrect partial decryption without a proof is trusted
+	cheat := partials[1]
+	cheat.Value = group.Mul(cheat.Value, group.G)
+	decrypted, err = ElGamalCombine([]PartialDecryption{partials[0], cheat}, ct, pub, false)
+	if err != nil {
+		t.Fatalf("Error combining partial decryptions: %v", err)
+	}
+	if decrypted.Cmp(m) == 0 {
+		t.Errorf("Expected incorrect partial decryption to result in a wrong plaintext; got %d", decrypted)
+	}
+}
+
+func TestElGamalCombineInvalidInputs(t *testing.T) {
+	group := testGroup()
+	pub, shares, err := ElGamalGenerateKey(2, 3, group)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+
+	ct, err := ElGamalEncrypt(group.Commit(big.NewInt(7)), pub)
+	if err != nil {
+		t.Fatalf("Error encrypting message: %v", err)
+	}
+
+	partials := make([]PartialDecryption, 3)
+	for i, share := range shares {
+		partials[i], err = ElGamalPartialDecrypt(share, ct, group, true)
+		if err != nil {
+			t.Fatalf("Error creating partial decryption: %v", err)
+		}
+	}
+
+	_, err = ElGamalCombine(partials[:1], ct, pub, true)
+	if !errors.Is(err, secretshare.ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if too few partial decryptions given; got %v", err)
+	}
+
+	_, err = ElGamalCombine([]PartialDecryption{partials[0], partials[0]}, ct, pub, true)
+	if !errors.Is(err, gf.ErrDuplicatePoint) {
+		t.Errorf("Expected ErrDuplicatePoint if duplicate partial decryptions given; got %v", err)
+	}
+
+	// A partial decryption with a different share fails its proof
+	cheat := partials[1]
+	cheat.Value = group.Mul(cheat.Value, group.G)
+	_, err = ElGamalCombine([]PartialDecryption{partials[0], cheat}, ct, pub, true)
+	if err == nil {
+		t.Errorf("Expected error if partial decryption is incorrect; got none")
+	}
+
+	// Surplus partial decryptions are verified as well
+	_, err = ElGamalCombine([]PartialDecryption{partials[0], partials[2], cheat}, ct, pub, true)
+	if err == nil {
+		t.Errorf("Expected error if surplus partial decryption is incorrect; got none")
+	}
+
+	_, err = ElGamalCombine(partials, ElGamalCiphertext{C1: ct.C1}, pub, true)
+	if err == nil {
+		t.Errorf("Expected error if ciphertext is incomplete; got none")
+	}
+
+	_, err = ElGamalEncrypt(big.NewInt(2038), pub)
+	if err == nil {
+		t.Errorf("Expected error if message is not in the group; got none")
+	}
+
+	_, err = ElGamalPartialDecrypt(shares[0], ElGamalCiphertext{C1: big.NewInt(2038), C2: big.NewInt(1)}, group, false)
+	if err == nil {
+		t.Errorf("Expected error if ciphertext is not in the group; got none")
+	}
+
+	_, err = ElGamalPartialDecrypt(secretshare.Share{ID: 1}, ct, group, false)
+	if !errors.Is(err, secretshare.ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if key share has no value; got %v", err)
+	}
+}
+
+func TestDLEQProof(t *testing.T) {
+	group := testGroup()
+	h := group.Commit(big.NewInt(123))
+	s := big.NewInt(77)
+
+	proof, err := proveDLEQ(s, group.G, h, group)
+	if err != nil {
+		t.Fatalf("Error creating proof: %v", err)
+	}
+
+	a := group.Exp(group.G, s)
+	b := group.Exp(h, s)
+	if !verifyDLEQ(proof, group.G, a, h, b, group) {
+		t.Errorf("Expected proof to pass verification; did not")
+	}
+
+	// Different logarithms of a and b
+	b = group.Exp(h, big.NewInt(78))
+	if verifyDLEQ(proof, group.G, a, h, b, group) {
+		t.Errorf("Expected proof for different logarithms to fail verification; did not")
+	}
+}
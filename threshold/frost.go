@@ -0,0 +1,466 @@
+package threshold
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+	"sort"
+)
+
+// Point is a point on the elliptic curve used for FROST signatures.
+type Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// FROSTPublicKey is the public key of a Schnorr key pair whose private key is
+// shared among n parties.
+type FROSTPublicKey struct {
+	// Public key `Y = x G` of the private key `x`
+	Y Point
+	// Number of parties required to sign
+	Threshold int
+	// Verification keys `s_i G` of each party's key share `s_i`, by ID
+	VerificationKeys map[int]Point
+}
+
+// FROSTNonce is a party's secret pair of nonces `(d, e)` for one signing
+// session. A nonce must only ever be used for a single signature.
+type FROSTNonce struct {
+	// ID of the party
+	ID int
+	D  *big.Int
+	E  *big.Int
+}
+
+// FROSTCommitment is a party's public commitment `(d G, e G)` to its nonces,
+// which it publishes in the first round of signing.
+type FROSTCommitment struct {
+	// ID of the party
+	ID int
+	D  Point
+	E  Point
+}
+
+// FROSTSignatureShare is a party's share `z_i` of a signature, which it
+// publishes in the second round of signing.
+type FROSTSignatureShare struct {
+	// ID of the party
+	ID int
+	Z  *big.Int
+}
+
+// SchnorrSignature is a standard Schnorr signature `(R, z)`, which verifies as
+// `z G = R + c Y` with the challenge `c = H(R, Y, message)`.
+type SchnorrSignature struct {
+	R Point
+	Z *big.Int
+}
+
+// frostCurve returns the curve FROST signatures are created on, and its scalar
+// field.
+func frostCurve() (elliptic.Curve, gf.Field) {
+	return elliptic.P256(), gf.P256Order()
+}
+
+// FROSTGenerateKey generates a Schnorr key pair on the P-256 curve, whose
+// private key is shared among n parties using `secretshare.TOutOfN` over the
+// group order.
+//
+// The dealer generating the key learns the private key, and must discard it
+// along with all shares it does not hand out.
+//
+// Returns the public key, including each party's verification key, and the
+// key shares of all parties.
+// An error is returned if any of the requirements of `secretshare.TOutOfN` are
+// violated.
+func FROSTGenerateKey(t int, n int) (FROSTPublicKey, []secretshare.Share, error) {
+	curve, field := frostCurve()
+	pub := FROSTPublicKey{Threshold: t}
+
+	x, err := randomScalar(field)
+	if err != nil {
+		return pub, nil, err
+	}
+
+	shares, _, err := secretshare.TOutOfN(x, t, n, field)
+	if err != nil {
+		return pub, nil, err
+	}
+
+	pub.Y = baseMul(curve, x)
+	pub.VerificationKeys = make(map[int]Point, n)
+	for _, share := range shares {
+		pub.VerificationKeys[share.ID] = baseMul(curve, share.Value) // s_i G
+	}
+
+	return pub, shares, nil
+}
+
+// FROSTCommit performs the first round of signing, generating a fresh pair of
+// nonces for the party holding `share`.
+//
+// The nonces are kept secret until the second round, while the commitment is
+// sent to all signers.
+func FROSTCommit(share secretshare.Share) (FROSTNonce, FROSTCommitment, error) {
+	curve, field := frostCurve()
+	nonce := FROSTNonce{ID: share.ID}
+	commitment := FROSTCommitment{ID: share.ID}
+
+	var err error
+	if nonce.D, err = randomScalar(field); err != nil {
+		return nonce, commitment, err
+	}
+	if nonce.E, err = randomScalar(field); err != nil {
+		return nonce, commitment, err
+	}
+
+	commitment.D = baseMul(curve, nonce.D)
+	commitment.E = baseMul(curve, nonce.E)
+
+	return nonce, commitment, nil
+}
+
+// FROSTSign performs the second round of signing, calculating the party's
+// signature share of a message.
+//
+// With the binding factor `rho_i = H(i, message, commitments)`, the group
+// commitment `R = Sum for j [ D_j + rho_j E_j ]` and the challenge
+// `c = H(R, Y, message)`, the signature share is:
+// `z_i = d_i + e_i rho_i + l_i(0) s_i c`
+//
+// The nonce is cleared after use, such that it cannot accidentally be used for
+// a second signature, which would leak the key share.
+//
+// It is required that:
+// - the commitments are those of exactly t signers, including the caller
+// - the nonce belongs to the caller's commitment and has not been used
+//
+// An error is returned if any of the requirements are violated.
+func FROSTSign(share secretshare.Share, nonce *FROSTNonce, message []byte, commitments []FROSTCommitment, pub FROSTPublicKey) (FROSTSignatureShare, error) {
+	curve, field := frostCurve()
+	sigShare := FROSTSignatureShare{ID: share.ID}
+
+	if share.Value == nil || !field.IsGroupElement(share.Value) {
+		return sigShare, fmt.Errorf("%w: value of share with ID %d", secretshare.ErrNotInField, share.ID)
+	}
+
+	if nonce.D == nil || nonce.E == nil {
+		return sigShare, fmt.Errorf("Nonce has already been used")
+	}
+
+	if nonce.ID != share.ID {
+		return sigShare, fmt.Errorf("Nonce of ID %d cannot be used with share of ID %d", nonce.ID, share.ID)
+	}
+
+	session, err := newFROSTSession(message, commitments, pub)
+	if err != nil {
+		return sigShare, err
+	}
+
+	i, ok := session.index[share.ID]
+	if !ok {
+		return sigShare, fmt.Errorf("Share with ID %d is not among the signers", share.ID)
+	}
+
+	own := session.commitments[i]
+	if !pointEqual(own.D, baseMul(curve, nonce.D)) || !pointEqual(own.E, baseMul(curve, nonce.E)) {
+		return sigShare, fmt.Errorf("Nonce does not match commitment of ID %d", share.ID)
+	}
+
+	z := field.Mul(nonce.E, session.rhos[i])                                           // e_i rho_i
+	z = field.Add(nonce.D, z)                                                          // d_i + e_i rho_i
+	z = field.Add(z, field.Mul(session.lambdas[i], field.Mul(share.Value, session.c))) // + l_i(0) s_i c
+	sigShare.Z = z
+
+	nonce.D = nil
+	nonce.E = nil
+
+	return sigShare, nil
+}
+
+// FROSTVerifyShare verifies a signature share against the signer's
+// verification key `Y_i`, by checking that:
+// `z_i G = D_i + rho_i E_i + l_i(0) c Y_i`
+//
+// This allows identifying signers which submitted an invalid share.
+func FROSTVerifyShare(sigShare FROSTSignatureShare, message []byte, commitments []FROSTCommitment, pub FROSTPublicKey) bool {
+	session, err := newFROSTSession(message, commitments, pub)
+	if err != nil {
+		return false
+	}
+
+	return session.verifyShare(sigShare)
+}
+
+// FROSTAggregate aggregates the signature shares of all signers into a
+// standard Schnorr signature `(R, z)`, with `z = Sum for i [ z_i ]`.
+//
+// Each share is verified, see `FROSTVerifyShare`.
+//
+// Returns an error if the shares do not match the signers' commitments, or if
+// any of them fails verification.
+func FROSTAggregate(sigShares []FROSTSignatureShare, message []byte, commitments []FROSTCommitment, pub FROSTPublicKey) (SchnorrSignature, error) {
+	_, field := frostCurve()
+	var sig SchnorrSignature
+
+	session, err := newFROSTSession(message, commitments, pub)
+	if err != nil {
+		return sig, err
+	}
+
+	if len(sigShares) != len(commitments) {
+		return sig, fmt.Errorf("Got %d signature shares for %d signers", len(sigShares), len(commitments))
+	}
+
+	seen := make(map[int]bool)
+	var invalid []int
+	var z = &big.Int{}
+	for _, sigShare := range sigShares {
+		if seen[sigShare.ID] {
+			return sig, fmt.Errorf("%w %d supplied", secretshare.ErrDuplicateID, sigShare.ID)
+		}
+		seen[sigShare.ID] = true
+
+		if !session.verifyShare(sigShare) {
+			invalid = append(invalid, sigShare.ID)
+			continue
+		}
+
+		z = field.Add(z, sigShare.Z)
+	}
+	if len(invalid) > 0 {
+		return sig, fmt.Errorf("Signature shares with IDs %v failed verification", invalid)
+	}
+
+	sig.R = session.r
+	sig.Z = z
+
+	return sig, nil
+}
+
+// VerifySchnorr verifies a Schnorr signature of a message under the public
+// key `y`, by checking that `z G = R + c Y`.
+func VerifySchnorr(sig SchnorrSignature, message []byte, y Point) bool {
+	curve, field := frostCurve()
+
+	if sig.Z == nil || !field.IsGroupElement(sig.Z) {
+		return false
+	}
+
+	if !onCurve(curve, sig.R) || !onCurve(curve, y) {
+		return false
+	}
+
+	c := frostChallenge(sig.R, y, message)
+	expected := addPoints(curve, sig.R, scalarMul(curve, y, c)) // R + c Y
+
+	return pointEqual(baseMul(curve, sig.Z), expected)
+}
+
+// frostSession holds the values of a signing session which all signers and
+// the aggregator derive from the message and the signers' commitments.
+type frostSession struct {
+	pub         FROSTPublicKey
+	commitments []FROSTCommitment
+	index       map[int]int
+	rhos        []*big.Int // Binding factors, by index
+	lambdas     []*big.Int // Lagrange coefficients at 0, by index
+	r           Point      // Group commitment
+	c           *big.Int   // Challenge
+}
+
+// newFROSTSession derives the binding factors, the group commitment and the
+// challenge of a signing session.
+//
+// The commitments are sorted by ID, such that all signers derive the same
+// values regardless of the order they received them in.
+//
+// Returns an error if the number of signers does not match the threshold, if
+// their IDs are not unique, non-zero IDs with a verification key, or if any
+// commitment is not a point on the curve.
+func newFROSTSession(message []byte, commitments []FROSTCommitment, pub FROSTPublicKey) (*frostSession, error) {
+	curve, field := frostCurve()
+
+	if len(commitments) != pub.Threshold {
+		return nil, fmt.Errorf("%w: exactly %d signers required; got %d", secretshare.ErrThreshold, pub.Threshold, len(commitments))
+	}
+
+	sorted := make([]FROSTCommitment, len(commitments))
+	copy(sorted, commitments)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	session := &frostSession{
+		pub:         pub,
+		commitments: sorted,
+		index:       make(map[int]int, len(sorted)),
+		rhos:        make([]*big.Int, len(sorted)),
+	}
+
+	xs := make([]*big.Int, len(sorted))
+	for i, commitment := range sorted {
+		if _, ok := pub.VerificationKeys[commitment.ID]; !ok {
+			return nil, fmt.Errorf("Signer with ID %d has no verification key", commitment.ID)
+		}
+
+		if !onCurve(curve, commitment.D) || !onCurve(curve, commitment.E) {
+			return nil, fmt.Errorf("Commitment of ID %d is not on the curve", commitment.ID)
+		}
+
+		session.index[commitment.ID] = i
+		xs[i] = big.NewInt(int64(commitment.ID))
+	}
+
+	lambdas, err := lagrangeAtZero(xs, field)
+	if err != nil {
+		return nil, err
+	}
+	session.lambdas = lambdas
+
+	// The binding factors commit each signer to the full set of commitments
+	// and the message, which prevents forgeries from concurrent sessions.
+	encoded := encodeCommitments(sorted)
+	r := Point{X: new(big.Int), Y: new(big.Int)}
+	for i, commitment := range sorted {
+		session.rhos[i] = hashToScalar(field, "rho", encodeID(commitment.ID), message, encoded)
+
+		bound := addPoints(curve, commitment.D, scalarMul(curve, commitment.E, session.rhos[i])) // D_i + rho_i E_i
+		r = addPoints(curve, r, bound)
+	}
+	session.r = r
+	session.c = frostChallenge(r, pub.Y, message)
+
+	return session, nil
+}
+
+// verifyShare verifies a signature share within the session, see
+// `FROSTVerifyShare`.
+func (session *frostSession) verifyShare(sigShare FROSTSignatureShare) bool {
+	curve, field := frostCurve()
+
+	i, ok := session.index[sigShare.ID]
+	if !ok || sigShare.Z == nil || !field.IsGroupElement(sigShare.Z) {
+		return false
+	}
+
+	commitment := session.commitments[i]
+	vk := session.pub.VerificationKeys[sigShare.ID]
+	if !onCurve(curve, vk) {
+		return false
+	}
+
+	expected := addPoints(curve, commitment.D, scalarMul(curve, commitment.E, session.rhos[i]))           // D_i + rho_i E_i
+	expected = addPoints(curve, expected, scalarMul(curve, vk, field.Mul(session.lambdas[i], session.c))) // + l_i(0) c Y_i
+
+	return pointEqual(baseMul(curve, sigShare.Z), expected)
+}
+
+// frostChallenge derives the Schnorr challenge `c = H(R, Y, message)`.
+func frostChallenge(r Point, y Point, message []byte) *big.Int {
+	curve, field := frostCurve()
+
+	return hashToScalar(field, "challenge", elliptic.Marshal(curve, r.X, r.Y), elliptic.Marshal(curve, y.X, y.Y), message)
+}
+
+// hashToScalar hashes a domain separation tag and a list of byte strings into
+// an element of the field.
+//
+// Each string is prefixed with its length, such that different lists never
+// hash the same. 64 bits more than the size of the field are hashed, which
+// makes the bias of the reduction negligible.
+func hashToScalar(field gf.Field, domain string, data ...[]byte) *big.Int {
+	blocks := (field.Order().BitLen() + 64 + 255) / 256
+
+	var digest []byte
+	for block := uint32(0); block < uint32(blocks); block++ {
+		h := sha256.New()
+		h.Write([]byte(domain))
+		binary.Write(h, binary.BigEndian, block)
+		for _, d := range data {
+			binary.Write(h, binary.BigEndian, uint64(len(d)))
+			h.Write(d)
+		}
+		digest = h.Sum(digest)
+	}
+
+	var x = &big.Int{}
+	x.SetBytes(digest)
+	x.Mod(x, field.Order())
+
+	return x
+}
+
+// encodeID encodes a party's ID as 8 big-endian bytes.
+func encodeID(id int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+
+	return b
+}
+
+// encodeCommitments encodes a list of commitments, in order.
+func encodeCommitments(commitments []FROSTCommitment) []byte {
+	curve, _ := frostCurve()
+
+	var out []byte
+	for _, commitment := range commitments {
+		out = append(out, encodeID(commitment.ID)...)
+		out = append(out, elliptic.Marshal(curve, commitment.D.X, commitment.D.Y)...)
+		out = append(out, elliptic.Marshal(curve, commitment.E.X, commitment.E.Y)...)
+	}
+
+	return out
+}
+
+// randomScalar returns a random non-zero element of the field.
+func randomScalar(field gf.Field) (*big.Int, error) {
+	for {
+		x, err := field.Rand()
+		if err != nil {
+			return nil, err
+		}
+
+		if x.Sign() != 0 {
+			return x, nil
+		}
+	}
+}
+
+// onCurve checks if a point is a point on the curve other than the point at
+// infinity.
+func onCurve(curve elliptic.Curve, p Point) bool {
+	return p.X != nil && p.Y != nil && curve.IsOnCurve(p.X, p.Y)
+}
+
+// pointEqual checks if two points are equal.
+func pointEqual(a Point, b Point) bool {
+	return a.X.Cmp(b.X) == 0 && a.Y.Cmp(b.Y) == 0
+}
+
+// baseMul calculates `k G` for the base point G of the curve.
+//
+// The point arithmetic uses the deprecated low-level methods of
+// `elliptic.Curve`, as explained in the package documentation.
+func baseMul(curve elliptic.Curve, k *big.Int) Point {
+	x, y := curve.ScalarBaseMult(k.Bytes())
+
+	return Point{X: x, Y: y}
+}
+
+// scalarMul calculates `k P` for a point P on the curve.
+func scalarMul(curve elliptic.Curve, p Point, k *big.Int) Point {
+	x, y := curve.ScalarMult(p.X, p.Y, k.Bytes())
+
+	return Point{X: x, Y: y}
+}
+
+// addPoints calculates `P + Q`, where the point at infinity is (0, 0).
+func addPoints(curve elliptic.Curve, p Point, q Point) Point {
+	x, y := curve.Add(p.X, p.Y, q.X, q.Y)
+
+	return Point{X: x, Y: y}
+}
@@ -0,0 +1,176 @@
+package threshold
+
+import (
+	"errors"
+	"github.com/lavode/secret-sharing/gf"
+	"github.com/lavode/secret-sharing/secretshare"
+	"math/big"
+	"testing"
+)
+
+// frostSign runs both rounds of signing among the parties holding `shares`.
+func frostSign(t *testing.T, shares []secretshare.Share, message []byte, pub FROSTPublicKey) ([]FROSTSignatureShare, []FROSTCommitment) {
+	nonces := make([]FROSTNonce, len(shares))
+	commitments := make([]FROSTCommitment, len(shares))
+	for i, share := range shares {
+		var err error
+		nonces[i], commitments[i], err = FROSTCommit(share)
+		if err != nil {
+			t.Fatalf("Error creating commitment: %v", err)
+		}
+	}
+
+	sigShares := make([]FROSTSignatureShare, len(shares))
+	for i, share := range shares {
+		var err error
+		sigShares[i], err = FROSTSign(share, &nonces[i], message, commitments, pub)
+		if err != nil {
+			t.Fatalf("Error creating signature share: %v", err)
+		}
+	}
+
+	return sigShares, commitments
+}
+
+func TestFROST(t *testing.T) {
+	pub, shares, err := FROSTGenerateKey(3, 5)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	message := []byte("transfer 42 coins")
+
+	for _, signers := range [][]secretshare.Share{
+		{shares[0], shares[1], shares[2]},
+		{shares[4], shares[0], shares[3]},
+	} {
+		sigShares, commitments := frostSign(t, signers, message, pub)
+
+		for _, sigShare := range sigShares {
+			if !FROSTVerifyShare(sigShare, message, commitments, pub) {
+				t.Errorf("Expected signature share of ID %d to pass verification; did not", sigShare.ID)
+			}
+		}
+
+		sig, err := FROSTAggregate(sigShares, message, commitments, pub)
+		if err != nil {
+			t.Fatalf("Error aggregating signature: %v", err)
+		}
+
+		if !VerifySchnorr(sig, message, pub.Y) {
+			t.Errorf("Expected signature to pass verification; did not")
+		}
+
+		if VerifySchnorr(sig, []byte("transfer 43 coins"), pub.Y) {
+			t.Errorf("Expected signature of different message to fail verification; did not")
+		}
+	}
+}
+
+func TestFROSTMatchesSingleSigner(t *testing.T) {
+	curve, field := frostCurve()
+	pub, shares, err := FROSTGenerateKey(2, 3)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+
+	// The public key is that of the private key the shares interpolate to,
+	// which a single signer could sign with directly.
+	x, err := secretshare.TOutOfNRecover(shares[:2], gf.P256Order())
+	if err != nil {
+		t.Fatalf("Error recovering private key: %v", err)
+	}
+	if !pointEqual(baseMul(curve, x), pub.Y) {
+		t.Fatalf("Expected public key to match shared private key")
+	}
+
+	k := big.NewInt(12345)
+	r := baseMul(curve, k)
+	message := []byte("message")
+	z := field.Add(k, field.Mul(frostChallenge(r, pub.Y, message), x))
+	if !VerifySchnorr(SchnorrSignature{R: r, Z: z}, message, pub.Y) {
+		t.Errorf("Expected single-signer signature to pass verification; did not")
+	}
+}
+
+func TestFROSTInvalidInputs(t *testing.T) {
+	pub, shares, err := FROSTGenerateKey(2, 3)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	message := []byte("message")
+
+	nonces := make([]FROSTNonce, 2)
+	commitments := make([]FROSTCommitment, 2)
+	for i := range nonces {
+		nonces[i], commitments[i], err = FROSTCommit(shares[i])
+		if err != nil {
+			t.Fatalf("Error creating commitment: %v", err)
+		}
+	}
+
+	_, err = FROSTSign(shares[0], &nonces[0], message, commitments[:1], pub)
+	if !errors.Is(err, secretshare.ErrThreshold) {
+		t.Errorf("Expected ErrThreshold if too few signers; got %v", err)
+	}
+
+	noValue := shares[0]
+	noValue.Value = nil
+	_, err = FROSTSign(noValue, &nonces[0], message, commitments, pub)
+	if !errors.Is(err, secretshare.ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if key share has no value; got %v", err)
+	}
+
+	outOfField := shares[0]
+	outOfField.Value = gf.P256Order().Order()
+	_, err = FROSTSign(outOfField, &nonces[0], message, commitments, pub)
+	if !errors.Is(err, secretshare.ErrNotInField) {
+		t.Errorf("Expected ErrNotInField if key share is not in field; got %v", err)
+	}
+
+	_, err = FROSTSign(shares[2], &nonces[0], message, commitments, pub)
+	if err == nil {
+		t.Errorf("Expected error if nonce belongs to different party; got none")
+	}
+
+	_, err = FROSTSign(shares[0], &nonces[1], message, commitments, pub)
+	if err == nil {
+		t.Errorf("Expected error if nonce does not match commitment; got none")
+	}
+
+	sigShare, err := FROSTSign(shares[0], &nonces[0], message, commitments, pub)
+	if err != nil {
+		t.Fatalf("Error creating signature share: %v", err)
+	}
+
+	_, err = FROSTSign(shares[0], &nonces[0], message, commitments, pub)
+	if err == nil {
+		t.Errorf("Expected error if nonce is reused; got none")
+	}
+
+	other, err := FROSTSign(shares[1], &nonces[1], message, commitments, pub)
+	if err != nil {
+		t.Fatalf("Error creating signature share: %v", err)
+	}
+
+	// A tampered share is detected and attributed
+	tampered := other
+	tampered.Z = new(big.Int).Add(other.Z, big.NewInt(1))
+	if FROSTVerifyShare(tampered, message, commitments, pub) {
+		t.Errorf("Expected tampered signature share to fail verification; did not")
+	}
+
+	_, err = FROSTAggregate([]FROSTSignatureShare{sigShare, tampered}, message, commitments, pub)
+	if err == nil {
+		t.Errorf("Expected error if signature share is invalid; got none")
+	}
+
+	_, err = FROSTAggregate([]FROSTSignatureShare{sigShare, sigShare}, message, commitments, pub)
+	if !errors.Is(err, secretshare.ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID if duplicate signature shares given; got %v", err)
+	}
+
+	_, err = FROSTAggregate([]FROSTSignatureShare{sigShare, other}, []byte("other"), commitments, pub)
+	if err == nil {
+		t.Errorf("Expected error if signature shares are for a different message; got none")
+	}
+}